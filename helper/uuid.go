@@ -0,0 +1,22 @@
+package helper
+
+import (
+	"crypto/sha1"
+	"fmt"
+)
+
+// urlNamespace is the RFC 4122 namespace for name-based UUIDs derived from URLs and other resource names.
+var urlNamespace = [16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// NameBasedUUID returns the RFC 4122 version 5 UUID of name, so the same name always yields the same UUID.
+func NameBasedUUID(name string) string {
+	h := sha1.New()
+	h.Write(urlNamespace[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,80 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccTag : tag of the entities created by the acceptance tests, which the sweepers delete
+const testAccTag = "terraform-provider-kong-acc"
+
+// testAccProviderFactories : providers of the acceptance tests, which only run with TF_ACC set, against the Kong node
+// of KONG_ADMIN_ADDR, e.g. the one of docker-compose.yml
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"kong": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+// testAccAddress returns the address of the Admin API of the Kong node the acceptance tests run against.
+func testAccAddress() string {
+	if address := os.Getenv("KONG_ADMIN_ADDR"); address != "" {
+		return address
+	}
+
+	return "http://localhost:8001"
+}
+
+// testAccClient returns a client of the Kong node the acceptance tests run against.
+func testAccClient() (*Client, error) {
+	return (&Config{Address: testAccAddress()}).Client()
+}
+
+// testAccPreCheck fails the acceptance test when the Kong node is not reachable.
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+
+	response, err := http.Get(testAccAddress() + "/status")
+	if err != nil {
+		t.Fatalf("Kong Admin API at %s not reachable, start one with docker compose up --wait: %s", testAccAddress(), err)
+	}
+	response.Body.Close()
+}
+
+// testAccConfig returns the configuration of an acceptance test step, preceded by the provider configuration for the
+// Kong node under test.
+func testAccConfig(format string, args ...interface{}) string {
+	return fmt.Sprintf("provider \"kong\" {\n  address = %q\n}\n\n", testAccAddress()) + fmt.Sprintf(format, args...)
+}
+
+// testAccCheckDestroyed checks that the entities of the resources of the given type, stored in collection, no longer
+// exist in Kong.
+func testAccCheckDestroyed(resourceType string, collection string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		client, err := testAccClient()
+		if err != nil {
+			return err
+		}
+
+		for _, r := range s.RootModule().Resources {
+			if r.Type != resourceType {
+				continue
+			}
+
+			response, err := client.New().Get(collection + "/" + pathSegment(r.Primary.ID)).ReceiveSuccess(nil)
+			if err != nil {
+				return err
+			}
+			if response.StatusCode != http.StatusNotFound {
+				return fmt.Errorf("%s %s still exists: %s", resourceType, r.Primary.ID, response.Status)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// refreshKey : context key marking the reads Terraform calls on refresh
+type refreshKey struct{}
+
+// refreshRead wraps the read Terraform calls on refresh, which bulk refreshes may serve from snapshots. Creates and
+// updates call the unwrapped read, so that they always read back the entity they just wrote.
+func refreshRead(read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return read(context.WithValue(ctx, refreshKey{}, true), d, meta)
+	}
+}
+
+// snapshots : collections listed once per provider instance during bulk refreshes, by workspace and collection
+type snapshots struct {
+	// tags restricts the listings to the entities having all of them, comma separated.
+	tags string
+
+	mu          sync.Mutex
+	collections map[string]*snapshot
+}
+
+// snapshot : the entities of a collection by ID and by name
+type snapshot struct {
+	once     sync.Once
+	entities map[string]json.RawMessage
+	err      error
+}
+
+// readEntity reads the entity at path, e.g. services/{id}, into entity. During bulk refreshes, the entity is served
+// from a snapshot of collection listed once, e.g. services, or key-auths for the key-auth credentials of every consumer.
+// Entities missing from the snapshot, e.g. filtered out by their tags, are read with a GET.
+func (c *Client) readEntity(ctx context.Context, workspace string, path string, collection string, id string, entity interface{}) (*http.Response, error) {
+	if c.snapshots != nil && ctx.Value(refreshKey{}) != nil {
+		if raw, ok := c.snapshot(ctx, workspace, collection)[id]; ok {
+			var err error
+			if c.strict {
+				err = strictDecoder{}.Decode(&http.Response{Body: io.NopCloser(bytes.NewReader(raw))}, entity)
+			} else {
+				err = json.Unmarshal(raw, entity)
+			}
+
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK"}, err
+		}
+	}
+
+	return c.strictWorkspace(workspace).Get(path).ReceiveSuccess(entity)
+}
+
+// snapshot returns the entities of the collection, listing it on first use. Collections which can't be listed, e.g.
+// for lack of permissions, have an empty snapshot so that their entities are read one by one.
+func (c *Client) snapshot(ctx context.Context, workspace string, collection string) map[string]json.RawMessage {
+	c.snapshots.mu.Lock()
+	s, ok := c.snapshots.collections[workspace+"/"+collection]
+	if !ok {
+		s = &snapshot{}
+		c.snapshots.collections[workspace+"/"+collection] = s
+	}
+	c.snapshots.mu.Unlock()
+
+	s.once.Do(func() {
+		request := c.Workspace(workspace)
+		if c.snapshots.tags != "" {
+			request = request.QueryStruct(&struct {
+				Tags string `url:"tags"`
+			}{Tags: c.snapshots.tags})
+		}
+
+		var entities []json.RawMessage
+		if _, s.err = listAll(request, c.pageSize, collection, &entities); s.err != nil {
+			tflog.Warn(ctx, "bulk refresh listing failed, reading entities one by one", map[string]interface{}{
+				"collection": collection,
+				"error":      s.err.Error(),
+			})
+			return
+		}
+
+		s.entities = make(map[string]json.RawMessage, len(entities))
+		for _, raw := range entities {
+			keys := &struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			}{}
+			if err := json.Unmarshal(raw, keys); err != nil {
+				continue
+			}
+
+			s.entities[keys.ID] = raw
+			if keys.Name != "" {
+				s.entities[keys.Name] = raw
+			}
+		}
+
+		tflog.Debug(ctx, "bulk refresh snapshot", map[string]interface{}{
+			"collection": collection,
+			"entities":   len(entities),
+		})
+	})
+
+	return s.entities
+}
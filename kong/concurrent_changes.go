@@ -0,0 +1,57 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// entityTimestamps : timestamps maintained by Kong on most entities
+type entityTimestamps struct {
+	UpdatedAt int `json:"updated_at"`
+}
+
+// checkConcurrentChanges compares the updated_at of the entity at path with the one recorded by the last refresh, so
+// that updates and deletes don't silently overwrite changes made since with decK, the Admin API or Kong Manager.
+//
+// Changes are reported as a warning, or as an error when fail_on_concurrent_changes is set. Entities without
+// updated_at, e.g. on older Kong versions, and entities which no longer exist are not checked.
+func checkConcurrentChanges(d *schema.ResourceData, client *Client, entity string, path string) diag.Diagnostics {
+	old, _ := d.GetChange("updated_at")
+	known := old.(int)
+	if known == 0 {
+		return nil
+	}
+
+	live := &entityTimestamps{}
+
+	response, err := client.Workspace(d.Get("workspace").(string)).Get(path).ReceiveSuccess(live)
+	if err != nil {
+		return diag.Errorf("error while reading %s: %s", entity, err.Error())
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil
+	} else if response.StatusCode != http.StatusOK {
+		return diag.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	if live.UpdatedAt == 0 || live.UpdatedAt == known {
+		return nil
+	}
+
+	severity := diag.Warning
+	if client.failOnConcurrentChanges {
+		severity = diag.Error
+	}
+
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("%s %s was modified outside of Terraform", entity, d.Id()),
+		Detail: fmt.Sprintf("It was updated at %s, after the last refresh which saw it updated at %s. Those changes are overwritten by this apply: review them with terraform plan -refresh-only.",
+			time.Unix(int64(live.UpdatedAt), 0).UTC().Format(time.RFC3339), time.Unix(int64(known), 0).UTC().Format(time.RFC3339)),
+	}}
+}
@@ -1,15 +1,283 @@
 package kong
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type Config struct {
 	Address  string
 	Username string
 	Password string
+
+	// ValidateReferences checks at plan time that referenced entities exist, see validate_references.
+	ValidateReferences bool
+
+	// FailOnConcurrentChanges turns the warnings of checkConcurrentChanges into errors, see fail_on_concurrent_changes.
+	FailOnConcurrentChanges bool
+
+	// FailOnMissingEntities fails refreshes finding an entity deleted outside of Terraform, see fail_on_missing_entities.
+	FailOnMissingEntities bool
+
+	// Strict fails reads returning fields the provider doesn't model, see strict.
+	Strict bool
+
+	// BulkRefresh serves refreshes from collections listed once, restricted to the entities having all of
+	// BulkRefreshTags when set, see bulk_refresh.
+	BulkRefresh     bool
+	BulkRefreshTags []string
+
+	// DataPlanesTimeout makes creates, updates and deletes wait up to this long for the data planes of a hybrid
+	// control plane to apply the change, see wait_for_data_planes. Zero doesn't wait.
+	DataPlanesTimeout time.Duration
+
+	// PageSize is the number of entities requested per page when listing collections, maxPageSize when zero.
+	PageSize int
+
+	// MaxIdleConnsPerHost and IdleConnTimeout tune the connection pool of the transport, which keeps connections alive
+	// so that applies with many resources don't pay for a TLS handshake per request. Zero keeps the Go defaults.
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// DisableHTTP2 restricts the transport to HTTP/1.1, which it otherwise upgrades to HTTP/2 over TLS.
+	DisableHTTP2 bool
+
+	// Doer sends the Admin API requests, an HTTP client using the transport above when nil. Tests point it at a fake
+	// Kong, e.g. the client of an httptest.Server.
+	Doer sling.Doer
+}
+
+// Client : provider meta shared by all resources, wrapping the Kong Admin API client
+type Client struct {
+	*sling.Sling
+
+	// doer sends the requests of the clients derived with WithContext.
+	doer sling.Doer
+
+	// validateReferences enables the plan time checks of validateReferences.
+	validateReferences bool
+
+	// failOnConcurrentChanges makes checkConcurrentChanges fail instead of warning.
+	failOnConcurrentChanges bool
+
+	// failOnMissingEntities makes the reads wrapped by failOnMissingEntity fail instead of clearing the ID.
+	failOnMissingEntities bool
+
+	// strict makes strictWorkspace decode responses with strictDecoder.
+	strict bool
+
+	// dataPlanesTimeout is how long waitForDataPlanes waits, zero when disabled.
+	dataPlanesTimeout time.Duration
+
+	// dataPlanesChanges serializes the changes waiting for the data planes, nil unless enabled.
+	dataPlanesChanges *sync.Mutex
+
+	// pageSize is the number of entities requested per page by listAll.
+	pageSize int
+
+	// snapshots are the collections listed by bulk refreshes, nil unless enabled.
+	snapshots *snapshots
+
+	// plugins and schemas are shared by the clients derived with WithContext.
+	plugins *enabledPlugins
+	schemas *pluginSchemas
+}
+
+// enabledPlugins : plugins enabled on the Kong node, fetched once per provider instance
+type enabledPlugins struct {
+	once  sync.Once
+	names []string
+	err   error
+}
+
+func (c *Config) Client() (*Client, error) {
+	var doer sling.Doer = &http.Client{Transport: c.transport()}
+	if c.Doer != nil {
+		doer = c.Doer
+	}
+	doer = kongErrorDoer{doer: doer}
+
+	// Paths are resolved relative to the address, which must end with a slash for an Admin API served under a base
+	// path, e.g. https://host/kong-admin/, to keep it.
+	address := c.Address
+	if !strings.HasSuffix(address, "/") {
+		address += "/"
+	}
+
+	client := &Client{
+		Sling:                   sling.New().Doer(doer).SetBasicAuth(c.Username, c.Password).Base(address),
+		doer:                    doer,
+		validateReferences:      c.ValidateReferences,
+		failOnConcurrentChanges: c.FailOnConcurrentChanges,
+		failOnMissingEntities:   c.FailOnMissingEntities,
+		strict:                  c.Strict,
+		pageSize:                maxPageSize,
+		dataPlanesTimeout:       c.DataPlanesTimeout,
+		plugins:                 &enabledPlugins{},
+		schemas:                 &pluginSchemas{schemas: map[string]*pluginSchema{}},
+	}
+
+	if c.PageSize > 0 {
+		client.pageSize = c.PageSize
+	}
+
+	if c.DataPlanesTimeout > 0 {
+		client.dataPlanesChanges = &sync.Mutex{}
+	}
+
+	if c.BulkRefresh {
+		client.snapshots = &snapshots{
+			tags:        strings.Join(c.BulkRefreshTags, ","),
+			collections: map[string]*snapshot{},
+		}
+	}
+
+	return client, nil
 }
 
-func (c *Config) Client() (*sling.Sling, error) {
-	return sling.New().SetBasicAuth(c.Username, c.Password).Base(c.Address), nil
+// transport returns the transport of the Admin API client, shared by all its requests.
+func (c *Config) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+		if transport.MaxIdleConns < c.MaxIdleConnsPerHost {
+			transport.MaxIdleConns = c.MaxIdleConnsPerHost
+		}
+	}
+
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+
+	if c.DisableHTTP2 {
+		// A non-nil empty map disables the HTTP/2 upgrade of TLS connections.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// WithContext returns a client whose requests are bound to ctx, so that they are aborted when Terraform cancels the
+// operation or when its timeout expires.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	derived := *c
+	derived.Sling = c.New().Doer(contextDoer{ctx: ctx, doer: c.doer})
+
+	return &derived
+}
+
+// contextDoer : sling.Doer sending every request with a context
+type contextDoer struct {
+	ctx  context.Context
+	doer sling.Doer
+}
+
+// Do sends the request and logs the operation it performs on the entity, see TF_LOG.
+func (d contextDoer) Do(req *http.Request) (*http.Response, error) {
+	entity, id := adminAPIEntity(req.URL.Path)
+	fields := map[string]interface{}{
+		"entity":    entity,
+		"operation": adminAPIOperations[req.Method],
+		"method":    req.Method,
+		"path":      req.URL.Path,
+	}
+	if id != "" {
+		fields["id"] = id
+	}
+
+	start := time.Now()
+	response, err := d.doer.Do(req.WithContext(d.ctx))
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Warn(d.ctx, "Kong Admin API request failed", fields)
+		return response, err
+	}
+
+	fields["status_code"] = response.StatusCode
+	tflog.Debug(d.ctx, "Kong Admin API request", fields)
+
+	return response, nil
+}
+
+// adminAPIOperations : CRUD operation performed by each method of the Admin API
+var adminAPIOperations = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodGet:    "read",
+	http.MethodPut:    "upsert",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+// adminAPIEntity returns the innermost entity type of an Admin API path along with its ID when the path designates a
+// single entity, e.g. routes and the route ID for /services/{service}/routes/{route}.
+func adminAPIEntity(path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		if _, ok := adminAPIEntities[segments[i]]; !ok {
+			continue
+		}
+		if i+1 < len(segments) {
+			return segments[i], segments[i+1]
+		}
+		return segments[i], ""
+	}
+
+	return path, ""
+}
+
+// adminAPIEntities : collections of the Admin API, used to find the entity a request is about
+var adminAPIEntities = map[string]struct{}{
+	"services": {}, "routes": {}, "consumers": {}, "consumer_groups": {}, "plugins": {}, "certificates": {},
+	"ca_certificates": {}, "snis": {}, "upstreams": {}, "targets": {}, "acls": {}, "basic-auth": {}, "hmac-auth": {},
+	"jwt": {}, "key-auth": {}, "mtls-auth": {}, "oauth2": {}, "health": {},
+}
+
+// pathSegment escapes an ID or a name for use as a single segment of an Admin API path. Colons are escaped as well,
+// so that a segment such as a host:port target isn't resolved as a URL with a scheme.
+func pathSegment(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), ":", "%3A")
+}
+
+// Workspace returns a new request scoped to the given Kong Enterprise workspace, or to the default one when empty.
+func (c *Client) Workspace(workspace string) *sling.Sling {
+	if workspace == "" {
+		return c.New()
+	}
+
+	return c.New().Path(pathSegment(workspace) + "/")
+}
+
+// EnabledPlugins returns the names of the plugins enabled on the Kong node. The list is fetched once per provider
+// instance.
+func (c *Client) EnabledPlugins() ([]string, error) {
+	c.plugins.once.Do(func() {
+		enabled := &struct {
+			EnabledPlugins []string `json:"enabled_plugins"`
+		}{}
+
+		response, err := c.New().Get("plugins/enabled").ReceiveSuccess(enabled)
+		if err != nil {
+			c.plugins.err = err
+		} else if response.StatusCode != http.StatusOK {
+			c.plugins.err = fmt.Errorf("unexpected status code received: %s", response.Status)
+		}
+
+		c.plugins.names = enabled.EnabledPlugins
+	})
+
+	return c.plugins.names, c.plugins.err
 }
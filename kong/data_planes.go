@@ -0,0 +1,160 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dghubble/sling"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataPlaneLastSeen : how recently a data plane must have pinged the control plane to be waited for, data planes
+// pinging every 30 seconds
+const dataPlaneLastSeen = time.Minute
+
+// dataPlanePollInterval : delay between two listings of the data planes while waiting for them
+const dataPlanePollInterval = 2 * time.Second
+
+// dataPlane : data plane of a hybrid control plane as returned by /clustering/data-planes
+type dataPlane struct {
+	ID         string `json:"id"`
+	Hostname   string `json:"hostname"`
+	ConfigHash string `json:"config_hash"`
+	LastSeen   int64  `json:"last_seen"`
+}
+
+func (p dataPlane) String() string {
+	return fmt.Sprintf("%s (%s)", p.Hostname, p.ID)
+}
+
+// connectedDataPlanes returns the data planes which recently pinged the control plane by ID, reporting false when the
+// node isn't a hybrid control plane.
+func (c *Client) connectedDataPlanes() (map[string]dataPlane, bool, error) {
+	var planes []dataPlane
+
+	found, err := listAll(c.New(), c.pageSize, "clustering/data-planes", &planes)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	connected := make(map[string]dataPlane, len(planes))
+	for _, p := range planes {
+		if time.Since(time.Unix(p.LastSeen, 0)) <= dataPlaneLastSeen {
+			connected[p.ID] = p
+		}
+	}
+
+	return connected, true, nil
+}
+
+// localAttributes : attributes of the resources which only change the behaviour of the provider, updating them alone
+// changing nothing Kong applies
+var localAttributes = []string{"protect", "force_destroy", "upsert", "adopt_on_conflict"}
+
+// waitForDataPlanes wraps the create, update and delete of a resource so that, when the provider sets
+// wait_for_data_planes, they return once every connected data plane reports a configuration other than the one it ran
+// before the change, or fail after data_planes_timeout. Data planes disconnecting meanwhile are not waited for.
+//
+// The control plane doesn't report the hash of the configuration it pushes, so the change is recognized by the data
+// planes moving off their previous hash. Changes waiting for the data planes are therefore applied one at a time,
+// another change landing in between ending the wait early otherwise. Nothing is waited for when no request changing an
+// entity succeeded, e.g. deleting an entity already gone, or when only the attributes in local changed.
+func waitForDataPlanes(mutate func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, local ...string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if mutate == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*Client)
+		if client.dataPlanesTimeout == 0 || (len(local) > 0 && !d.HasChangesExcept(local...)) {
+			return mutate(ctx, d, meta)
+		}
+
+		client.dataPlanesChanges.Lock()
+		defer client.dataPlanesChanges.Unlock()
+
+		before, found, err := client.WithContext(ctx).connectedDataPlanes()
+		if err != nil {
+			return append(mutate(ctx, d, meta), diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Not waiting for the data planes",
+				Detail:   fmt.Sprintf("Listing the data planes of the control plane failed: %s", err),
+			})
+		}
+
+		recorder := &writeRecorder{doer: client.doer}
+		recording := *client
+		recording.doer = recorder
+		recording.Sling = client.New().Doer(recorder)
+
+		diags := mutate(ctx, d, &recording)
+		if diags.HasError() || !recorder.wrote.Load() || !found || len(before) == 0 {
+			return diags
+		}
+
+		return append(diags, client.WithContext(ctx).awaitDataPlanes(ctx, before)...)
+	}
+}
+
+// writeRecorder : sling.Doer recording whether a request changing an entity succeeded
+type writeRecorder struct {
+	doer  sling.Doer
+	wrote atomic.Bool
+}
+
+func (d *writeRecorder) Do(req *http.Request) (*http.Response, error) {
+	response, err := d.doer.Do(req)
+	if err == nil && req.Method != http.MethodGet && req.Method != http.MethodHead && response.StatusCode < http.StatusMultipleChoices {
+		d.wrote.Store(true)
+	}
+
+	return response, err
+}
+
+// awaitDataPlanes polls the data planes until none of those connected still reports the configuration hash it had
+// before.
+func (c *Client) awaitDataPlanes(ctx context.Context, before map[string]dataPlane) diag.Diagnostics {
+	deadline := time.Now().Add(c.dataPlanesTimeout)
+
+	for {
+		current, _, err := c.connectedDataPlanes()
+		if err != nil {
+			return diag.Errorf("error while waiting for the data planes: %s", err)
+		}
+
+		var pending []string
+		for id, p := range current {
+			if b, ok := before[id]; ok && b.ConfigHash == p.ConfigHash {
+				pending = append(pending, p.String())
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+		sort.Strings(pending)
+
+		if time.Now().Add(dataPlanePollInterval).After(deadline) {
+			return diag.Errorf("timed out after %s waiting for data planes to apply the new configuration: %s",
+				c.dataPlanesTimeout, strings.Join(pending, ", "))
+		}
+
+		tflog.Debug(ctx, "waiting for data planes", map[string]interface{}{
+			"pending": pending,
+		})
+
+		select {
+		case <-ctx.Done():
+			return diag.Errorf("interrupted while waiting for data planes to apply the new configuration: %s", ctx.Err())
+		case <-time.After(dataPlanePollInterval):
+		}
+	}
+}
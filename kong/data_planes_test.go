@@ -0,0 +1,72 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// dataPlanesClient returns a client of kong waiting for the data planes, which report a configuration hash that never
+// changes, so that every wait times out right away.
+func dataPlanesClient(kong *fakeKong) *Client {
+	kong.respond(http.MethodGet, "clustering/data-planes", http.StatusOK, map[string]interface{}{
+		"data": []interface{}{
+			map[string]interface{}{
+				"id":          "00000000-0000-4000-8000-0000000000d1",
+				"hostname":    "dp-1",
+				"config_hash": "a9a166c59873245db8f1a747ba9a80a7",
+				"last_seen":   time.Now().Unix(),
+			},
+		},
+	})
+
+	return kong.clientWith(Config{DataPlanesTimeout: time.Second})
+}
+
+func TestWaitForDataPlanesWrite(t *testing.T) {
+	kong := newFakeKong(t)
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"name": "example",
+		"host": "example.com",
+	})
+
+	create := waitForDataPlanes(resourceKongServiceCreate)
+	err := diagnosticsError(t, create(context.Background(), d, dataPlanesClient(kong)))
+	if !strings.Contains(err, "waiting for data planes to apply the new configuration: dp-1") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestWaitForDataPlanesDeleteNotFound(t *testing.T) {
+	kong := newFakeKong(t)
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"host": "example.com",
+	})
+	d.SetId("00000000-0000-4000-8000-000000000042")
+
+	remove := waitForDataPlanes(resourceKongServiceDelete)
+	expectNoError(t, remove(context.Background(), d, dataPlanesClient(kong)))
+}
+
+func TestWaitForDataPlanesLocalUpdate(t *testing.T) {
+	kong := newFakeKong(t)
+	id := kong.put("services", map[string]interface{}{"name": "example", "host": "example.com"})
+
+	r := resourceKongService()
+	d := r.Data(&terraform.InstanceState{ID: id, Attributes: map[string]string{
+		"name":    "example",
+		"host":    "example.com",
+		"protect": "false",
+	}})
+	if err := d.Set("protect", true); err != nil {
+		t.Fatal(err)
+	}
+
+	update := waitForDataPlanes(resourceKongServiceUpdate, localAttributes...)
+	expectNoError(t, update(context.Background(), d, dataPlanesClient(kong)))
+}
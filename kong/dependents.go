@@ -0,0 +1,87 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dghubble/sling"
+)
+
+// dependentEntity : entity referencing the entity being deleted, e.g. a route of a service
+type dependentEntity struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Target   string `json:"target,omitempty"`
+	Username string `json:"username,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Group    string `json:"group,omitempty"`
+}
+
+// String returns the ID of the entity along with the most descriptive of its names.
+func (e dependentEntity) String() string {
+	for _, name := range []string{e.Name, e.Target, e.Username, e.Key, e.Group} {
+		if name != "" {
+			return fmt.Sprintf("%s (%s)", name, e.ID)
+		}
+	}
+
+	return e.ID
+}
+
+// listDependents returns every entity of the collection at path. A missing collection, e.g. the credentials of a
+// plugin which isn't enabled, has no entities.
+func listDependents(request *sling.Sling, size int, path string) ([]dependentEntity, error) {
+	var entities []dependentEntity
+
+	if _, err := listAll(request, size, path, &entities); err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// deleteDependents deletes every entity of the collection at path, each entity being deleted at path/{id}.
+func deleteDependents(request *sling.Sling, size int, path string) error {
+	entities, err := listDependents(request, size, path)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entities {
+		response, err := request.New().Path(path + "/").Delete(pathSegment(e.ID)).ReceiveSuccess(nil)
+		if err != nil {
+			return fmt.Errorf("error while deleting %s/%s: %s", path, e.ID, err)
+		}
+
+		if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("unexpected status code received while deleting %s/%s: %s", path, e.ID, response.Status)
+		}
+	}
+
+	return nil
+}
+
+// checkNoDependents fails with the list of the entities of the collection at path when there are any, so that deleting
+// their parent fails with an actionable error rather than with the bare status code Kong answers with.
+func checkNoDependents(request *sling.Sling, size int, path string, parent string, dependents string) error {
+	entities, err := listDependents(request, size, path)
+	if err != nil {
+		return err
+	}
+
+	if len(entities) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(entities))
+	for _, e := range entities {
+		names = append(names, e.String())
+	}
+
+	return fmt.Errorf("%s still has %d %s: %s. Delete them first, or set force_destroy to true to delete them along with it",
+		parent, len(entities), dependents, strings.Join(names, ", "))
+}
+
+// consumerCredentials : collections of the credentials and ACL groups of a consumer, under /consumers/{consumer}/
+var consumerCredentials = []string{"acls", "basic-auth", "hmac-auth", "jwt", "key-auth", "mtls-auth", "oauth2"}
@@ -0,0 +1,51 @@
+package kong
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// httpMethods : methods the cors plugin can allow
+var httpMethods = []string{"GET", "HEAD", "PUT", "PATCH", "POST", "DELETE", "OPTIONS", "TRACE", "CONNECT"}
+
+// httpMethodRegexp : methods routes and the transformer plugins accept, any uppercase token like PURGE or the WebDAV
+// methods
+var httpMethodRegexp = regexp.MustCompile(`^[A-Z]+$`)
+
+// jwtAlgorithms : algorithms of the tokens verified with JWT credentials and signed by the jwt-signer plugin
+var jwtAlgorithms = []string{
+	"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512", "EdDSA",
+}
+
+// upstreamAlgorithms : load balancing algorithms of an upstream
+var upstreamAlgorithms = []string{"round-robin", "consistent-hashing", "least-connections", "latency"}
+
+// upstreamHashInputs : what an upstream can hash on for consistent hashing
+var upstreamHashInputs = []string{"none", "consumer", "ip", "header", "cookie", "path", "query_arg", "uri_capture"}
+
+// healthcheckTypes : protocols of the active and passive health checks of an upstream
+var healthcheckTypes = []string{"tcp", "http", "https", "grpc", "grpcs"}
+
+// validateOneOf returns a ValidateDiagFunc accepting only the given values, listing them when the value is invalid.
+func validateOneOf(values ...string) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value, _ := v.(string)
+		for _, allowed := range values {
+			if value == allowed {
+				return nil
+			}
+		}
+
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value %q", value),
+			Detail:        fmt.Sprintf("Expected one of: %s.", strings.Join(values, ", ")),
+			AttributePath: path,
+		}}
+	}
+}
@@ -0,0 +1,63 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/dghubble/sling"
+)
+
+// kongError : error returned by the Admin API in the body of failed responses
+type kongError struct {
+	Message string                 `json:"message"`
+	Name    string                 `json:"name"`
+	Code    int                    `json:"code"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// Error returns the message of the error, which for schema violations already lists the invalid fields.
+func (e *kongError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	if e.Name != "" && len(e.Fields) > 0 {
+		return e.Name + " " + encodeConfigValue(e.Fields)
+	}
+
+	return e.Name
+}
+
+// kongErrorDoer : sling.Doer appending the error returned by Kong to the status of failed responses, so that every
+// diagnostic built from the status reads e.g. "400 Bad Request: schema violation (config.minute: expected a number)"
+// instead of the bare status.
+type kongErrorDoer struct {
+	doer sling.Doer
+}
+
+func (d kongErrorDoer) Do(req *http.Request) (*http.Response, error) {
+	response, err := d.doer.Do(req)
+	if err != nil || response.StatusCode < http.StatusBadRequest {
+		return response, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The body stays readable for the callers decoding it themselves.
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	kongErr := &kongError{}
+	if json.Unmarshal(body, kongErr) == nil {
+		if message := kongErr.Error(); message != "" {
+			response.Status += ": " + message
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,269 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeKongTimestamp : created_at and updated_at of the entities created by fakeKong
+const fakeKongTimestamp = 1700000000
+
+// fakeKong : in-memory Admin API for the unit tests of the resources, storing the entities it receives and recording
+// every request
+//
+// Paths with an odd number of segments, e.g. services or services/{id}/routes, are collections, which are listed and
+// created in. Paths with an even number of segments, e.g. services/{id}, are entities, looked up by ID or name. Nested
+// collections share the entities of the top-level collection of the same name.
+type fakeKong struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu        sync.Mutex
+	ids       int
+	entities  map[string]map[string]map[string]interface{}
+	responses map[string]fakeResponse
+	requests  []fakeRequest
+}
+
+// fakeRequest : request received by fakeKong, with its JSON body decoded
+type fakeRequest struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// fakeResponse : response fakeKong answers a request with instead of serving it from its entities
+type fakeResponse struct {
+	status int
+	body   interface{}
+}
+
+func newFakeKong(t *testing.T) *fakeKong {
+	k := &fakeKong{
+		t:         t,
+		entities:  map[string]map[string]map[string]interface{}{},
+		responses: map[string]fakeResponse{},
+	}
+
+	k.server = httptest.NewServer(http.HandlerFunc(k.serveHTTP))
+	t.Cleanup(k.server.Close)
+
+	return k
+}
+
+// client returns a provider client sending its requests to the fake.
+func (k *fakeKong) client() *Client {
+	return k.clientWith(Config{})
+}
+
+// clientWith returns a provider client configured with config, sending its requests to the fake.
+func (k *fakeKong) clientWith(config Config) *Client {
+	config.Address = k.server.URL
+	config.Doer = k.server.Client()
+
+	client, err := config.Client()
+	if err != nil {
+		k.t.Fatal(err)
+	}
+
+	return client
+}
+
+// respond makes the fake answer every request with method on path with status and body.
+func (k *fakeKong) respond(method string, path string, status int, body interface{}) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.responses[method+" "+path] = fakeResponse{status: status, body: body}
+}
+
+// put stores an entity in collection as if created outside of Terraform, returning its ID.
+func (k *fakeKong) put(collection string, entity map[string]interface{}) string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.store(collection, entity)
+}
+
+// get returns the entity of collection with the given ID or name, nil when it doesn't exist.
+func (k *fakeKong) get(collection string, id string) map[string]interface{} {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.lookup(collection, id)
+}
+
+// requestsTo returns the requests received with method on path.
+func (k *fakeKong) requestsTo(method string, path string) []fakeRequest {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var requests []fakeRequest
+	for _, r := range k.requests {
+		if r.Method == method && r.Path == path {
+			requests = append(requests, r)
+		}
+	}
+
+	return requests
+}
+
+// lastRequestTo returns the last request received with method on path, failing the test when there is none.
+func (k *fakeKong) lastRequestTo(method string, path string) fakeRequest {
+	k.t.Helper()
+
+	requests := k.requestsTo(method, path)
+	if len(requests) == 0 {
+		k.t.Fatalf("no %s %s request received", method, path)
+	}
+
+	return requests[len(requests)-1]
+}
+
+func (k *fakeKong) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	path := strings.Trim(r.URL.Path, "/")
+
+	var body map[string]interface{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			k.write(w, http.StatusBadRequest, map[string]interface{}{"message": err.Error()})
+			return
+		}
+	}
+	k.requests = append(k.requests, fakeRequest{Method: r.Method, Path: path, Body: body})
+
+	if response, ok := k.responses[r.Method+" "+path]; ok {
+		k.write(w, response.status, response.body)
+		return
+	}
+
+	segments := strings.Split(path, "/")
+	if len(segments)%2 == 1 {
+		k.serveCollection(w, r.Method, segments[len(segments)-1], body)
+	} else {
+		k.serveEntity(w, r.Method, segments[len(segments)-2], segments[len(segments)-1], body)
+	}
+}
+
+func (k *fakeKong) serveCollection(w http.ResponseWriter, method string, collection string, body map[string]interface{}) {
+	switch method {
+	case http.MethodGet:
+		data := make([]interface{}, 0, len(k.entities[collection]))
+		for _, e := range k.entities[collection] {
+			data = append(data, e)
+		}
+		k.write(w, http.StatusOK, map[string]interface{}{"data": data})
+	case http.MethodPost:
+		if name, ok := body["name"].(string); ok && k.lookup(collection, name) != nil {
+			k.write(w, http.StatusConflict, map[string]interface{}{"message": fmt.Sprintf("UNIQUE violation detected on '{name=%q}'", name)})
+			return
+		}
+		id := k.store(collection, body)
+		k.write(w, http.StatusCreated, k.entities[collection][id])
+	default:
+		k.write(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+func (k *fakeKong) serveEntity(w http.ResponseWriter, method string, collection string, id string, body map[string]interface{}) {
+	entity := k.lookup(collection, id)
+
+	switch method {
+	case http.MethodGet:
+		if entity == nil {
+			k.write(w, http.StatusNotFound, map[string]interface{}{"message": "Not found"})
+			return
+		}
+		k.write(w, http.StatusOK, entity)
+	case http.MethodPut:
+		if body == nil {
+			body = map[string]interface{}{}
+		}
+		if entity != nil {
+			body["id"] = entity["id"]
+		} else {
+			body["id"] = id
+		}
+		id = k.store(collection, body)
+		k.write(w, http.StatusOK, k.entities[collection][id])
+	case http.MethodPatch:
+		if entity == nil {
+			k.write(w, http.StatusNotFound, map[string]interface{}{"message": "Not found"})
+			return
+		}
+		for key, value := range body {
+			entity[key] = value
+		}
+		entity["updated_at"] = entity["updated_at"].(float64) + 1
+		k.write(w, http.StatusOK, entity)
+	case http.MethodDelete:
+		if entity == nil {
+			k.write(w, http.StatusNotFound, map[string]interface{}{"message": "Not found"})
+			return
+		}
+		delete(k.entities[collection], entity["id"].(string))
+		k.write(w, http.StatusNoContent, nil)
+	default:
+		k.write(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// store saves a copy of entity in collection, filling in its ID and timestamps when missing.
+func (k *fakeKong) store(collection string, entity map[string]interface{}) string {
+	stored := map[string]interface{}{
+		"created_at": float64(fakeKongTimestamp),
+		"updated_at": float64(fakeKongTimestamp),
+	}
+	for key, value := range entity {
+		stored[key] = value
+	}
+
+	id, _ := stored["id"].(string)
+	if id == "" {
+		k.ids++
+		id = fmt.Sprintf("00000000-0000-4000-8000-%012d", k.ids)
+		stored["id"] = id
+	}
+
+	if k.entities[collection] == nil {
+		k.entities[collection] = map[string]map[string]interface{}{}
+	}
+	k.entities[collection][id] = stored
+
+	return id
+}
+
+func (k *fakeKong) lookup(collection string, id string) map[string]interface{} {
+	if entity, ok := k.entities[collection][id]; ok {
+		return entity
+	}
+
+	for _, entity := range k.entities[collection] {
+		if entity["name"] == id || entity["username"] == id {
+			return entity
+		}
+	}
+
+	return nil
+}
+
+func (k *fakeKong) write(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil || status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		k.t.Errorf("error while encoding the response: %v", err)
+	}
+}
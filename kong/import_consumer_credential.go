@@ -1,16 +1,23 @@
 package kong
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func ImportConsumerCredential(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func ImportConsumerCredential(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("expected a string in the format \"<consumer_id>/<credential_id>\" to import")
+
+	switch len(parts) {
+	case 2:
+	case 3:
+		_ = d.Set("workspace", parts[0])
+		parts = parts[1:]
+	default:
+		return nil, fmt.Errorf("expected a string in the format \"<consumer_id>/<credential_id>\" or \"<workspace>/<consumer_id>/<credential_id>\" to import")
 	}
 
 	d.Set("consumer", parts[0])
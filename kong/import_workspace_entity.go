@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importWorkspaceEntity returns an importer accepting the ID of the entity, optionally prefixed with its workspace:
+// "<id>" or "<workspace>/<id>".
+func importWorkspaceEntity(entity string) schema.StateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+
+		switch len(parts) {
+		case 1:
+		case 2:
+			_ = d.Set("workspace", parts[0])
+			d.SetId(parts[1])
+		default:
+			return nil, fmt.Errorf("expected a string in the format \"<%[1]s_id>\" or \"<workspace>/<%[1]s_id>\" to import", entity)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importNamedEntity returns an importer accepting the ID or the unique name of the entity, optionally prefixed with its
+// workspace, which Kong both resolves on /{collection}/{id or name}. The name is replaced with the ID.
+func importNamedEntity(entity string, collection string, name string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		// Names containing a slash must be prefixed with their workspace, e.g. default/team/alice.
+		if parts := strings.SplitN(d.Id(), "/", 2); len(parts) == 2 {
+			_ = d.Set("workspace", parts[0])
+			d.SetId(parts[1])
+		}
+
+		request := meta.(*Client).WithContext(ctx).Workspace(d.Get("workspace").(string))
+
+		found := &struct {
+			ID string `json:"id"`
+		}{}
+
+		response, err := request.Path(collection + "/").Get(pathSegment(d.Id())).ReceiveSuccess(found)
+		if err != nil {
+			return nil, fmt.Errorf("error while importing %s: %s", entity, err)
+		}
+
+		if response.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("no %s found with id or %s %s", entity, name, d.Id())
+		} else if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code received: %s", response.Status)
+		}
+
+		d.SetId(found.ID)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
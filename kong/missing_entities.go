@@ -0,0 +1,30 @@
+package kong
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// failOnMissingEntity wraps the read Terraform calls on refresh so that, when the provider sets
+// fail_on_missing_entities, an entity deleted outside of Terraform fails the refresh instead of being silently
+// recreated by the next apply. Creates and updates call the unwrapped read, see readAfterWrite.
+func failOnMissingEntity(resource string, read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		id := d.Id()
+
+		diags := read(ctx, d, meta)
+		if diags.HasError() || d.Id() != "" || !meta.(*Client).failOnMissingEntities {
+			return diags
+		}
+
+		d.SetId(id)
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "managed " + resource + " " + id + " was deleted outside of Terraform",
+			Detail:   "Recreate it by removing it from the state with terraform state rm, or set fail_on_missing_entities to false to let the next apply recreate it.",
+		})
+	}
+}
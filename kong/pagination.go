@@ -0,0 +1,51 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/dghubble/sling"
+)
+
+// maxPageSize : largest number of entities per page Kong serves, used unless page_size is set
+const maxPageSize = 1000
+
+// listAll appends every entity of the collection at path to entities, a pointer to a slice, following the offset Kong
+// returns until the last page of size entities. It reports false when the collection doesn't exist, e.g. when its
+// parent was deleted.
+func listAll(request *sling.Sling, size int, path string, entities interface{}) (bool, error) {
+	all := reflect.ValueOf(entities).Elem()
+
+	offset := ""
+	for {
+		data := reflect.New(all.Type())
+		page := &struct {
+			Data   interface{} `json:"data"`
+			Offset string      `json:"offset"`
+		}{Data: data.Interface()}
+
+		query := &struct {
+			Size   int    `url:"size"`
+			Offset string `url:"offset,omitempty"`
+		}{Size: size, Offset: offset}
+
+		response, err := request.New().QueryStruct(query).Get(path).ReceiveSuccess(page)
+		if err != nil {
+			return false, fmt.Errorf("error while listing %s: %s", path, err)
+		}
+
+		if response.StatusCode == http.StatusNotFound {
+			return false, nil
+		} else if response.StatusCode != http.StatusOK {
+			return false, fmt.Errorf("unexpected status code received: %s", response.Status)
+		}
+
+		all.Set(reflect.AppendSlice(all, data.Elem()))
+
+		if page.Offset == "" {
+			return true, nil
+		}
+		offset = page.Offset
+	}
+}
@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// pluginSchemas : schemas of the plugins, fetched from /schemas/plugins/{name} once per provider instance and plugin
+type pluginSchemas struct {
+	mu      sync.Mutex
+	schemas map[string]*pluginSchema
+}
+
+// pluginSchema : the properties of the config of a plugin
+type pluginSchema struct {
+	once   sync.Once
+	config []string
+	found  bool
+	err    error
+}
+
+// kongSchemaFields : fields of a Kong schema, each an object with the name of the field as its only key
+type kongSchemaFields []map[string]struct {
+	Type   string           `json:"type"`
+	Fields kongSchemaFields `json:"fields"`
+}
+
+// PluginConfigProperties returns the names of the config properties of a plugin, reporting false when the Kong node
+// doesn't know the plugin. Schemas are cached for the lifetime of the provider instance.
+func (c *Client) PluginConfigProperties(name string) ([]string, bool, error) {
+	c.schemas.mu.Lock()
+	s, ok := c.schemas.schemas[name]
+	if !ok {
+		s = &pluginSchema{}
+		c.schemas.schemas[name] = s
+	}
+	c.schemas.mu.Unlock()
+
+	s.once.Do(func() {
+		fields := &struct {
+			Fields kongSchemaFields `json:"fields"`
+		}{}
+
+		response, err := c.New().Path("schemas/plugins/").Get(pathSegment(name)).ReceiveSuccess(fields)
+		if err != nil {
+			s.err = err
+			return
+		}
+
+		switch response.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			return
+		default:
+			s.err = fmt.Errorf("unexpected status code received: %s", response.Status)
+			return
+		}
+
+		s.found = true
+		for _, field := range fields.Fields {
+			if config, ok := field["config"]; ok {
+				for _, property := range config.Fields {
+					for k := range property {
+						s.config = append(s.config, k)
+					}
+				}
+			}
+		}
+	})
+
+	return s.config, s.found, s.err
+}
@@ -0,0 +1,41 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// checkNotProtected fails the deletion of an entity whose protect attribute is set in the state, which also covers
+// replacements Terraform decides on its own, e.g. of tainted resources.
+func checkNotProtected(d *schema.ResourceData, entity string) diag.Diagnostics {
+	if !d.Get("protect").(bool) {
+		return nil
+	}
+
+	return diag.Errorf("%s %s is protected: set protect to false and apply before deleting or replacing it", entity, d.Id())
+}
+
+// preventProtectedReplacement fails the plan when it replaces a protected entity, i.e. when one of the given attributes,
+// which can't be updated in place, changes while protect is set in the state.
+func preventProtectedReplacement(entity string, forceNew ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		if protect, _ := d.GetChange("protect"); !protect.(bool) {
+			return nil
+		}
+
+		for _, k := range forceNew {
+			if d.HasChange(k) {
+				return fmt.Errorf("%s %s is protected and changing %s would replace it: set protect to false and apply first", entity, d.Id(), k)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package kong
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// readAfterWriteTimeout : how long the read following a create or an update retries while the entity is not found.
+// Hybrid mode and Cassandra-backed clusters may take a moment to make a write visible to every node.
+const readAfterWriteTimeout = 30 * time.Second
+
+// readAfterWrite reads the entity just created or updated, retrying with a growing delay while read reports it as not
+// found by clearing the ID, instead of dropping it from the state.
+func readAfterWrite(ctx context.Context, d *schema.ResourceData, meta interface{}, read schema.ReadContextFunc) diag.Diagnostics {
+	id := d.Id()
+	deadline := time.Now().Add(readAfterWriteTimeout)
+	delay := 250 * time.Millisecond
+
+	for {
+		diags := read(ctx, d, meta)
+		if diags.HasError() || d.Id() != "" {
+			return diags
+		}
+
+		d.SetId(id)
+
+		if time.Now().Add(delay).After(deadline) {
+			return diag.Errorf("%s not found after being written, still missing after %s", id, readAfterWriteTimeout)
+		}
+
+		tflog.Debug(ctx, "entity not found after being written, retrying", map[string]interface{}{
+			"id":    id,
+			"delay": delay.String(),
+		})
+		select {
+		case <-ctx.Done():
+			return diag.FromErr(ctx.Err())
+		case <-time.After(delay):
+		}
+
+		if delay < 4*time.Second {
+			delay *= 2
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// uuidRegexp : ids of the Kong entities
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateUUIDReference fails when a reference to another entity isn't a UUID, which usually means that the name of
+// the entity was given instead of its id.
+func validateUUIDReference(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+	if uuidRegexp.MatchString(value) {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("%s must be the id (a UUID) of the referenced entity, got %q: use its id attribute, e.g. kong_service.example.id, rather than its name", k, value)}
+}
+
+// entityReference : attribute of a resource holding the id of another entity, found in Kong under collection
+type entityReference struct {
+	attribute  string
+	collection string
+	entity     string
+}
+
+// pluginScopeReferences : entities a plugin can be scoped to
+var pluginScopeReferences = []entityReference{
+	{attribute: "service", collection: "services", entity: "Service"},
+	{attribute: "route", collection: "routes", entity: "Route"},
+	{attribute: "consumer", collection: "consumers", entity: "consumer"},
+	{attribute: "consumer_group", collection: "consumer_groups", entity: "consumer group"},
+}
+
+// validateReferences checks, when validate_references is enabled on the provider, that the entities referenced by
+// new or changed attributes exist, so that the plan fails naming the missing entity before the apply changes anything.
+// References to entities created by the same apply are unknown at plan time and left out.
+func validateReferences(references ...entityReference) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*Client)
+		if !ok || !client.validateReferences {
+			return nil
+		}
+
+		request := client.WithContext(ctx).Workspace(d.Get("workspace").(string))
+
+		for _, r := range references {
+			if !d.HasChange(r.attribute) || !d.NewValueKnown(r.attribute) {
+				continue
+			}
+
+			var ids []string
+			switch v := d.Get(r.attribute).(type) {
+			case string:
+				ids = []string{v}
+			case []interface{}:
+				ids = helper.ConvertInterfaceArrToStrings(v)
+			case *schema.Set:
+				ids = helper.ConvertInterfaceArrToStrings(v.List())
+			}
+
+			for _, id := range ids {
+				if id == "" {
+					continue
+				}
+
+				response, err := request.New().Path(r.collection + "/").Get(pathSegment(id)).ReceiveSuccess(nil)
+				if err != nil {
+					return fmt.Errorf("error while checking %s %s referenced by %s: %s", r.entity, id, r.attribute, err)
+				}
+
+				if response.StatusCode == http.StatusNotFound {
+					return fmt.Errorf("%s %s referenced by %s does not exist in Kong", r.entity, id, r.attribute)
+				} else if response.StatusCode != http.StatusOK {
+					return fmt.Errorf("unexpected status code received: %s", response.Status)
+				}
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,164 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKongService(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroyed("kong_service", "services"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(`
+resource "kong_service" "service" {
+  name = "tf-acc-service"
+  host = "example.com"
+  path = "/api"
+  tags = [%q]
+}
+`, testAccTag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kong_service.service", "host", "example.com"),
+					resource.TestCheckResourceAttr("kong_service.service", "path", "/api"),
+					resource.TestCheckResourceAttr("kong_service.service", "retries", "5"),
+				),
+			},
+			{
+				Config: testAccConfig(`
+resource "kong_service" "service" {
+  name    = "tf-acc-service"
+  host    = "changed.example.com"
+  retries = 3
+  tags    = [%q]
+}
+`, testAccTag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kong_service.service", "host", "changed.example.com"),
+					resource.TestCheckResourceAttr("kong_service.service", "retries", "3"),
+				),
+			},
+			{
+				ResourceName:            "kong_service.service",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"protect", "force_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccKongRoute(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroyed("kong_route", "routes"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(`
+resource "kong_service" "service" {
+  name = "tf-acc-route-service"
+  host = "example.com"
+  tags = [%[1]q]
+}
+
+resource "kong_route" "route" {
+  name    = "tf-acc-route"
+  service = kong_service.service.id
+  paths   = ["/api"]
+  methods = ["GET", "PURGE"]
+  tags    = [%[1]q]
+}
+`, testAccTag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kong_route.route", "paths.0", "/api"),
+					resource.TestCheckResourceAttr("kong_route.route", "methods.#", "2"),
+				),
+			},
+			{
+				Config: testAccConfig(`
+resource "kong_service" "service" {
+  name = "tf-acc-route-service"
+  host = "example.com"
+  tags = [%[1]q]
+}
+
+resource "kong_route" "route" {
+  name       = "tf-acc-route"
+  service    = kong_service.service.id
+  paths      = ["/api", "/v2"]
+  strip_path = false
+  tags       = [%[1]q]
+}
+`, testAccTag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kong_route.route", "paths.#", "2"),
+					resource.TestCheckResourceAttr("kong_route.route", "methods.#", "0"),
+					resource.TestCheckResourceAttr("kong_route.route", "strip_path", "false"),
+				),
+			},
+			{
+				ResourceName:            "kong_route.route",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"service_name"},
+			},
+		},
+	})
+}
+
+func TestAccKongConsumer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroyed("kong_consumer", "consumers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(`
+resource "kong_consumer" "consumer" {
+  username = "tf-acc-consumer"
+  tags     = [%[1]q]
+}
+
+resource "kong_consumer_key_auth_credential" "key_auth" {
+  consumer = kong_consumer.consumer.id
+  key      = "tf-acc-key"
+  tags     = [%[1]q]
+}
+`, testAccTag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kong_consumer.consumer", "username", "tf-acc-consumer"),
+					resource.TestCheckResourceAttr("kong_consumer_key_auth_credential.key_auth", "key", "tf-acc-key"),
+				),
+			},
+			{
+				Config: testAccConfig(`
+resource "kong_consumer" "consumer" {
+  username  = "tf-acc-consumer"
+  custom_id = "tf-acc-custom-id"
+  tags      = [%[1]q]
+}
+
+resource "kong_consumer_key_auth_credential" "key_auth" {
+  consumer = kong_consumer.consumer.id
+  key      = "tf-acc-changed-key"
+  tags     = [%[1]q]
+}
+`, testAccTag),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kong_consumer.consumer", "custom_id", "tf-acc-custom-id"),
+					resource.TestCheckResourceAttr("kong_consumer_key_auth_credential.key_auth", "key", "tf-acc-changed-key"),
+				),
+			},
+			{
+				ResourceName:            "kong_consumer.consumer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"protect", "force_destroy"},
+			},
+		},
+	})
+}
@@ -1,10 +1,12 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
-	"github.com/dghubble/sling"
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,16 +15,32 @@ type CACertificate struct {
 	Cert       string   `json:"cert,omitempty"`
 	CertDigest string   `json:"cert_digest,omitempty"`
 	Tags       []string `json:"tags"`
+	UpdatedAt  int      `json:"updated_at,omitempty"`
 }
 
 func resourceKongCACertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongCACertificateCreate,
-		Read:   resourceKongCACertificateRead,
-		Update: resourceKongCACertificateUpdate,
-		Delete: resourceKongCACertificateDelete,
+		CreateContext: resourceKongCACertificateCreate,
+		ReadContext:   resourceKongCACertificateRead,
+		UpdateContext: resourceKongCACertificateUpdate,
+		DeleteContext: resourceKongCACertificateDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceEntity("ca_certificate"),
+		},
+
+		CustomizeDiff: computeUpdatedAtOnChange,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the CA certificate belongs to. Defaults to the default workspace.",
+			},
+
 			"cert": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -37,51 +55,57 @@ func resourceKongCACertificate() *schema.Resource {
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
+
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the CA certificate was last updated.",
+			},
 		},
 	}
 }
 
-func resourceKongCACertificateCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCACertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	caCertificate := getCACertificateFromResourceData(d)
 
 	createdCACertificate := getCACertificateFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(caCertificate).Post("ca_certificates/").ReceiveSuccess(createdCACertificate)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(caCertificate).Post("ca_certificates/").ReceiveSuccess(createdCACertificate)
 	if error != nil {
-		return fmt.Errorf("error while creating caCertificate")
+		return diag.Errorf("error while creating caCertificate: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setCACertificateToResourceData(d, createdCACertificate)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongCACertificateRead)
 }
 
-func resourceKongCACertificateRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCACertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	caCertificate := getCACertificateFromResourceData(d)
 
-	response, error := sling.New().Path("ca_certificates/").Get(caCertificate.ID).ReceiveSuccess(caCertificate)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "ca_certificates/"+pathSegment(caCertificate.ID), "ca_certificates", caCertificate.ID, caCertificate)
 	if error != nil {
-		return fmt.Errorf("error while updating caCertificate")
+		return diag.Errorf("error while reading caCertificate: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setCACertificateToResourceData(d, caCertificate)
@@ -89,42 +113,52 @@ func resourceKongCACertificateRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceKongCACertificateUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCACertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "CA certificate", "ca_certificates/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	caCertificate := getCACertificateFromResourceData(d)
 
 	updatedCACertificate := getCACertificateFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(caCertificate).Path("ca_certificates/").Patch(caCertificate.ID).ReceiveSuccess(updatedCACertificate)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(caCertificate).Path("ca_certificates/").Patch(pathSegment(caCertificate.ID)).ReceiveSuccess(updatedCACertificate)
 	if error != nil {
-		return fmt.Errorf("error while updating caCertificate")
+		return diag.Errorf("error while updating caCertificate: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setCACertificateToResourceData(d, updatedCACertificate)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongCACertificateRead)...)
 }
 
-func resourceKongCACertificateDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCACertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "CA certificate", "ca_certificates/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	caCertificate := getCACertificateFromResourceData(d)
 
-	response, error := sling.New().Path("ca_certificates/").Delete(caCertificate.ID).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("ca_certificates/").Delete(pathSegment(caCertificate.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting caCertificate")
+		return diag.Errorf("error while deleting caCertificate: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getCACertificateFromResourceData(d *schema.ResourceData) *CACertificate {
@@ -132,6 +166,7 @@ func getCACertificateFromResourceData(d *schema.ResourceData) *CACertificate {
 		ID:         d.Id(),
 		Cert:       d.Get("cert").(string),
 		CertDigest: d.Get("cert_digest").(string),
+		Tags:       helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return caCertificate
@@ -141,4 +176,6 @@ func setCACertificateToResourceData(d *schema.ResourceData, caCertificate *CACer
 	d.SetId(caCertificate.ID)
 	d.Set("cert", caCertificate.Cert)
 	d.Set("cert_digest", caCertificate.CertDigest)
+	d.Set("tags", caCertificate.Tags)
+	d.Set("updated_at", caCertificate.UpdatedAt)
 }
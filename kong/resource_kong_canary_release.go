@@ -0,0 +1,357 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// canaryWeight : weight shared by the targets of a group for each percent of traffic sent to it, so that a group
+// receiving all the traffic has a total weight of 10000, well below the maximum weight of a target.
+const canaryWeight = 100
+
+// targetHealth : health of a target as returned by /upstreams/{upstream}/health
+type targetHealth struct {
+	Target string `json:"target"`
+	Health string `json:"health"`
+}
+
+func resourceKongCanaryRelease() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKongCanaryReleaseCreate,
+		ReadContext:   resourceKongCanaryReleaseRead,
+		UpdateContext: resourceKongCanaryReleaseUpdate,
+		DeleteContext: resourceKongCanaryReleaseDelete,
+
+		CustomizeDiff: correctCanaryDrift,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace of the upstream. Defaults to the default workspace.",
+			},
+
+			"upstream": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The unique identifier or the name of the upstream whose traffic is shifted between the stable and canary targets.",
+			},
+
+			"stable_targets": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Required:    true,
+				MinItems:    1,
+				Description: "The addresses (host:port) of the targets serving the current version. Missing targets are added to the upstream.",
+			},
+
+			"canary_targets": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Required:    true,
+				MinItems:    1,
+				Description: "The addresses (host:port) of the targets serving the new version. Missing targets are added to the upstream.",
+			},
+
+			"percentage": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+				Description:  "The percentage of the traffic to send to the canary targets once the apply completes. 100 completes the rollout and 0 rolls it back.",
+			},
+
+			"steps": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 100),
+				Description:  "The number of equal steps in which the traffic is shifted from the current percentage to percentage.",
+			},
+
+			"step_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1m",
+				ValidateFunc: validateDuration,
+				Description:  "How long to wait after each step before checking the health of the canary targets and moving on, e.g. 30s or 5m.",
+			},
+
+			"health_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to check that the canary targets are healthy after each step, using the health checks of the upstream.",
+			},
+
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to restore the percentage the apply started from when a step fails its health check.",
+			},
+
+			"current_percentage": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The percentage of the traffic currently sent to the canary targets, computed from the weights of the targets.",
+			},
+		},
+	}
+}
+
+func resourceKongCanaryReleaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("upstream").(string)
+	if workspace := d.Get("workspace").(string); workspace != "" {
+		name = workspace + "/" + name
+	}
+	d.SetId(helper.NameBasedUUID("canary-release/" + name))
+
+	if err := applyCanaryRelease(ctx, d, meta.(*Client).WithContext(ctx), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKongCanaryReleaseRead(ctx, d, meta)
+}
+
+func resourceKongCanaryReleaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	weights, found, err := getUpstreamTargetWeights(sling, d.Get("workspace").(string), d.Get("upstream").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("current_percentage", canaryPercentage(weights,
+		helper.ConvertInterfaceArrToStrings(d.Get("stable_targets").([]interface{})),
+		helper.ConvertInterfaceArrToStrings(d.Get("canary_targets").([]interface{}))))
+
+	return nil
+}
+
+func resourceKongCanaryReleaseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := applyCanaryRelease(ctx, d, meta.(*Client).WithContext(ctx), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		// Keep the previous percentage in the state so that the next apply tries again.
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
+
+	return resourceKongCanaryReleaseRead(ctx, d, meta)
+}
+
+// resourceKongCanaryReleaseDelete leaves the weights of the targets as they are, so that destroying the release
+// doesn't change where the traffic goes. Roll out to 100 or back to 0 before destroying it.
+func resourceKongCanaryReleaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// correctCanaryDrift plans an update when the weights of the targets were changed outside of Terraform, e.g. by a
+// manual rollback, so that the apply shifts the traffic back to percentage.
+func correctCanaryDrift(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if current, _ := d.GetChange("current_percentage"); current.(int) != d.Get("percentage").(int) {
+		return d.SetNewComputed("current_percentage")
+	}
+
+	return nil
+}
+
+// applyCanaryRelease shifts the traffic from the current percentage to the configured one step by step, checking the
+// health of the canary targets after each step.
+func applyCanaryRelease(ctx context.Context, d *schema.ResourceData, client *Client, timeout time.Duration) error {
+	workspace := d.Get("workspace").(string)
+	upstream := d.Get("upstream").(string)
+	stable := helper.ConvertInterfaceArrToStrings(d.Get("stable_targets").([]interface{}))
+	canary := helper.ConvertInterfaceArrToStrings(d.Get("canary_targets").([]interface{}))
+	percentage := d.Get("percentage").(int)
+	steps := d.Get("steps").(int)
+	healthCheck := d.Get("health_check").(bool)
+	interval, _ := time.ParseDuration(d.Get("step_interval").(string))
+
+	weights, found, err := getUpstreamTargetWeights(client, workspace, upstream)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("upstream %s not found", upstream)
+	}
+
+	start := canaryPercentage(weights, stable, canary)
+	deadline := time.Now().Add(timeout)
+
+	for step := 1; step <= steps; step++ {
+		current := start + int(math.Round(float64((percentage-start)*step)/float64(steps)))
+
+		tflog.Info(ctx, "canary release step", map[string]interface{}{
+			"upstream":   upstream,
+			"step":       step,
+			"steps":      steps,
+			"percentage": current,
+		})
+		if err := setCanaryWeights(client, workspace, upstream, stable, canary, current); err != nil {
+			return err
+		}
+
+		if !healthCheck && step == steps {
+			break
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("canary release of upstream %s timed out at %d%%, increase the timeout or reduce steps or step_interval", upstream, current)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("canary release of upstream %s interrupted at %d%%: %s", upstream, current, ctx.Err())
+		case <-time.After(interval):
+		}
+
+		if !healthCheck || current == 0 {
+			continue
+		}
+
+		if err := checkCanaryHealth(client, workspace, upstream, canary); err != nil {
+			if d.Get("rollback_on_failure").(bool) {
+				tflog.Warn(ctx, "canary release failed, rolling back", map[string]interface{}{
+					"upstream":   upstream,
+					"percentage": current,
+					"rollback":   start,
+					"error":      err.Error(),
+				})
+				if rollbackErr := setCanaryWeights(client, workspace, upstream, stable, canary, start); rollbackErr != nil {
+					return fmt.Errorf("canary release failed at %d%%: %s, and rolling back to %d%% failed: %s", current, err, start, rollbackErr)
+				}
+				return fmt.Errorf("canary release failed at %d%% and was rolled back to %d%%: %s", current, start, err)
+			}
+			return fmt.Errorf("canary release failed at %d%%: %s", current, err)
+		}
+	}
+
+	return nil
+}
+
+// setCanaryWeights splits the weight of the upstream between the stable and canary targets for the given percentage.
+// Weights are shared evenly between the targets of a group, a group without traffic having its targets at weight 0.
+func setCanaryWeights(client *Client, workspace string, upstream string, stable []string, canary []string, percentage int) error {
+	canaryTargetWeight := canaryWeight * percentage / len(canary)
+	stableTargetWeight := canaryWeight * (100 - percentage) / len(stable)
+
+	for _, target := range canary {
+		if _, err := setTargetWeight(client.Workspace(workspace), upstream, "", target, canaryTargetWeight); err != nil {
+			return err
+		}
+	}
+
+	for _, target := range stable {
+		if _, err := setTargetWeight(client.Workspace(workspace), upstream, "", target, stableTargetWeight); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getUpstreamTargetWeights returns the weight of every target of the upstream, reporting false when the upstream
+// doesn't exist.
+func getUpstreamTargetWeights(client *Client, workspace string, upstream string) (map[string]int, bool, error) {
+	var targets []Target
+
+	found, err := listAll(client.Workspace(workspace).Path("upstreams/").Path(pathSegment(upstream)+"/"), client.pageSize, "targets", &targets)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	weights := make(map[string]int, len(targets))
+	for _, t := range targets {
+		weights[t.Target] = t.Weight
+	}
+
+	return weights, true, nil
+}
+
+// canaryPercentage returns the share of the weight of the stable and canary targets carried by the canary targets.
+func canaryPercentage(weights map[string]int, stable []string, canary []string) int {
+	var stableTotal, canaryTotal int
+	for _, target := range stable {
+		stableTotal += weights[target]
+	}
+	for _, target := range canary {
+		canaryTotal += weights[target]
+	}
+
+	if stableTotal+canaryTotal == 0 {
+		return 0
+	}
+
+	return int(math.Round(float64(canaryTotal*100) / float64(stableTotal+canaryTotal)))
+}
+
+// checkCanaryHealth fails when a canary target is neither healthy nor without health checks.
+func checkCanaryHealth(client *Client, workspace string, upstream string, canary []string) error {
+	var health []targetHealth
+
+	found, err := listAll(client.Workspace(workspace).Path("upstreams/").Path(pathSegment(upstream)+"/"), client.pageSize, "health", &health)
+	if err != nil {
+		return fmt.Errorf("error while reading the health of upstream %s: %s", upstream, err)
+	}
+
+	if !found {
+		return fmt.Errorf("upstream %s not found", upstream)
+	}
+
+	statuses := make(map[string]string, len(health))
+	for _, h := range health {
+		statuses[h.Target] = h.Health
+	}
+
+	var unhealthy []string
+	for _, target := range canary {
+		switch status := statuses[target]; status {
+		case "HEALTHY", "HEALTHCHECKS_OFF":
+		case "":
+			unhealthy = append(unhealthy, target+" (not found)")
+		default:
+			unhealthy = append(unhealthy, target+" ("+status+")")
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("unhealthy canary targets: %s", strings.Join(unhealthy, ", "))
+	}
+
+	return nil
+}
+
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration such as 30s or 5m: %s", k, err)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package kong
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceKongCanaryReleaseDrift(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		update  bool
+	}{
+		{name: "in sync", current: "50", update: false},
+		{name: "weights changed outside of Terraform", current: "20", update: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resourceKongCanaryRelease()
+			state := &terraform.InstanceState{
+				ID: "canary",
+				Attributes: map[string]string{
+					"upstream":            "example",
+					"stable_targets.#":    "1",
+					"stable_targets.0":    "10.0.0.10:8000",
+					"canary_targets.#":    "1",
+					"canary_targets.0":    "10.0.1.10:8000",
+					"percentage":          "50",
+					"steps":               "1",
+					"step_interval":       "1m",
+					"health_check":        "true",
+					"rollback_on_failure": "true",
+					"current_percentage":  tt.current,
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"upstream":       "example",
+				"stable_targets": []interface{}{"10.0.0.10:8000"},
+				"canary_targets": []interface{}{"10.0.1.10:8000"},
+				"percentage":     50,
+			})
+
+			diff, err := r.Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if update := diff != nil && !diff.Empty(); update != tt.update {
+				t.Errorf("update planned: %t, want %t (diff %v)", update, tt.update, diff)
+			}
+		})
+	}
+}
@@ -1,31 +1,52 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type Certificate struct {
-	ID      string   `json:"id,omitempty"`
-	Cert    string   `json:"cert,omitempty"`
-	Key     string   `json:"key,omitempty"`
-	CertAlt string   `json:"cert_alt,omitempty"`
-	KeyAlt  string   `json:"key_alt,omitempty"`
-	Tags    []string `json:"tags"`
+	ID        string   `json:"id,omitempty"`
+	Cert      string   `json:"cert,omitempty"`
+	Key       string   `json:"key,omitempty"`
+	CertAlt   string   `json:"cert_alt,omitempty"`
+	KeyAlt    string   `json:"key_alt,omitempty"`
+	Tags      []string `json:"tags"`
+	UpdatedAt int      `json:"updated_at,omitempty"`
 }
 
 func resourceKongCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongCertificateCreate,
-		Read:   resourceKongCertificateRead,
-		Update: resourceKongCertificateUpdate,
-		Delete: resourceKongCertificateDelete,
+		CreateContext: resourceKongCertificateCreate,
+		ReadContext:   resourceKongCertificateRead,
+		UpdateContext: resourceKongCertificateUpdate,
+		DeleteContext: resourceKongCertificateDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceEntity("certificate"),
+		},
+
+		CustomizeDiff: customdiff.All(
+			computeUpdatedAtOnChange,
+			preventProtectedReplacement("certificate", "workspace"),
+		),
+
+		Timeouts: resourceTimeouts(20 * time.Minute),
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the certificate belongs to. Defaults to the default workspace.",
+			},
+
 			"cert": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -53,51 +74,71 @@ func resourceKongCertificate() *schema.Resource {
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
+
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the certificate was last updated.",
+			},
+
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the SNIs of the certificate before deleting it.",
+			},
+
+			"protect": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to refuse deleting or replacing the certificate, e.g. on terraform destroy, until protect is set back to false and applied.",
+			},
 		},
 	}
 }
 
-func resourceKongCertificateCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	certificate := getCertificateFromResourceData(d)
 
 	createdCertificate := getCertificateFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(certificate).Post("certificates/").ReceiveSuccess(createdCertificate)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(certificate).Post("certificates/").ReceiveSuccess(createdCertificate)
 	if error != nil {
-		return fmt.Errorf("error while creating certificate")
+		return diag.Errorf("error while creating certificate: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setCertificateToResourceData(d, createdCertificate)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongCertificateRead)
 }
 
-func resourceKongCertificateRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	certificate := getCertificateFromResourceData(d)
 
-	response, error := sling.New().Path("certificates/").Get(certificate.ID).ReceiveSuccess(certificate)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "certificates/"+pathSegment(certificate.ID), "certificates", certificate.ID, certificate)
 	if error != nil {
-		return fmt.Errorf("error while updating certificate")
+		return diag.Errorf("error while reading certificate: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setCertificateToResourceData(d, certificate)
@@ -105,42 +146,64 @@ func resourceKongCertificateRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceKongCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "certificate", "certificates/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	certificate := getCertificateFromResourceData(d)
 
 	updatedCertificate := getCertificateFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(certificate).Path("certificates/").Patch(certificate.ID).ReceiveSuccess(updatedCertificate)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(certificate).Path("certificates/").Patch(pathSegment(certificate.ID)).ReceiveSuccess(updatedCertificate)
 	if error != nil {
-		return fmt.Errorf("error while updating certificate")
+		return diag.Errorf("error while updating certificate: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setCertificateToResourceData(d, updatedCertificate)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongCertificateRead)...)
 }
 
-func resourceKongCertificateDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := checkNotProtected(d, "certificate"); diags.HasError() {
+		return diags
+	}
+
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "certificate", "certificates/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	certificate := getCertificateFromResourceData(d)
 
-	response, error := sling.New().Path("certificates/").Delete(certificate.ID).ReceiveSuccess(nil)
+	if d.Get("force_destroy").(bool) {
+		if err := deleteDependents(sling.Workspace(d.Get("workspace").(string)), sling.pageSize, "certificates/"+pathSegment(certificate.ID)+"/snis"); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := checkNoDependents(sling.Workspace(d.Get("workspace").(string)), sling.pageSize, "certificates/"+pathSegment(certificate.ID)+"/snis", "certificate "+certificate.ID, "SNIs"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("certificates/").Delete(pathSegment(certificate.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting certificate")
+		return diag.Errorf("error while deleting certificate: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getCertificateFromResourceData(d *schema.ResourceData) *Certificate {
@@ -150,7 +213,7 @@ func getCertificateFromResourceData(d *schema.ResourceData) *Certificate {
 		Key:     d.Get("key").(string),
 		CertAlt: d.Get("cert_alt").(string),
 		KeyAlt:  d.Get("key_alt").(string),
-		Tags:    helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:    helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return certificate
@@ -163,5 +226,6 @@ func setCertificateToResourceData(d *schema.ResourceData, certificate *Certifica
 	d.Set("cert_alt", certificate.CertAlt)
 	d.Set("key_alt", certificate.KeyAlt)
 	d.Set("tags", certificate.Tags)
+	d.Set("updated_at", certificate.UpdatedAt)
 
 }
@@ -1,97 +1,136 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type Consumer struct {
-	ID       string   `json:"id,omitempty"`
-	Username string   `json:"username,omitempty"`
-	CustomID string   `json:"custom_id,omitempty"`
-	Tags     []string `json:"tags"`
+	ID        string   `json:"id,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	CustomID  string   `json:"custom_id,omitempty"`
+	Tags      []string `json:"tags"`
+	UpdatedAt int      `json:"updated_at,omitempty"`
 }
 
 func resourceKongConsumer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongConsumerCreate,
-		Read:   resourceKongConsumerRead,
-		Update: resourceKongConsumerUpdate,
-		Delete: resourceKongConsumerDelete,
+		CreateContext: resourceKongConsumerCreate,
+		ReadContext:   resourceKongConsumerRead,
+		UpdateContext: resourceKongConsumerUpdate,
+		DeleteContext: resourceKongConsumerDelete,
+
+		Timeouts: resourceTimeouts(20 * time.Minute),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importNamedEntity("consumer", "consumers", "username"),
 		},
 
+		CustomizeDiff: customdiff.All(
+			computeUpdatedAtOnChange,
+			preventProtectedReplacement("consumer", "workspace", "custom_id"),
+		),
+
 		Schema: map[string]*schema.Schema{
-			"username": {
+			"workspace": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     nil,
-				Description: "The username of the consumer. You must send either this field or custom_id with the request.",
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the consumer belongs to. Defaults to the default workspace.",
+			},
+
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      nil,
+				AtLeastOneOf: []string{"username", "custom_id"},
+				Description:  "The username of the consumer. You must send either this field or custom_id with the request.",
 			},
 
 			"custom_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Default:     nil,
-				Description: "Field for storing an existing ID for the consumer, useful for mapping Kong with users in your existing database. You must send either this field or username with the request.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      nil,
+				AtLeastOneOf: []string{"username", "custom_id"},
+				Description:  "Field for storing an existing ID for the consumer, useful for mapping Kong with users in your existing database. You must send either this field or username with the request.",
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
+
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the consumer was last updated.",
+			},
+
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the credentials and ACL groups of the consumer before deleting it, instead of relying on Kong to delete them along with the consumer.",
+			},
+
+			"protect": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to refuse deleting or replacing the consumer, e.g. on terraform destroy, until protect is set back to false and applied.",
+			},
 		},
 	}
 }
 
-func resourceKongConsumerCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	consumer := getConsumerFromResourceData(d)
 
 	createdConsumer := new(Consumer)
 
-	response, error := sling.New().BodyJSON(consumer).Post("consumers/").ReceiveSuccess(createdConsumer)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(consumer).Post("consumers/").ReceiveSuccess(createdConsumer)
 	if error != nil {
-		return fmt.Errorf("error while creating consumer")
+		return diag.Errorf("error while creating consumer: %s", error)
 	}
 
 	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this consumer")
+		return diag.Errorf("409 Conflict - use terraform import to manage this consumer")
 	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setConsumerToResourceData(d, createdConsumer)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongConsumerRead)
 }
 
-func resourceKongConsumerRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	id := d.Id()
 	consumer := new(Consumer)
 
-	response, error := sling.New().Path("consumers/").Get(id).ReceiveSuccess(consumer)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "consumers/"+pathSegment(id), "consumers", id, consumer)
 	if error != nil {
-		return fmt.Errorf("error while updating consumer")
+		return diag.Errorf("error while reading consumer: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setConsumerToResourceData(d, consumer)
@@ -99,42 +138,64 @@ func resourceKongConsumerRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongConsumerUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "consumer", "consumers/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	consumer := getConsumerFromResourceData(d)
 
 	updatedConsumer := new(Consumer)
 
-	response, error := sling.New().BodyJSON(consumer).Patch("consumers/").Path(consumer.ID).ReceiveSuccess(updatedConsumer)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(consumer).Patch("consumers/").Path(pathSegment(consumer.ID)).ReceiveSuccess(updatedConsumer)
 	if error != nil {
-		return fmt.Errorf("error while updating consumer")
+		return diag.Errorf("error while updating consumer: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setConsumerToResourceData(d, updatedConsumer)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongConsumerRead)...)
 }
 
-func resourceKongConsumerDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := checkNotProtected(d, "consumer"); diags.HasError() {
+		return diags
+	}
+
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "consumer", "consumers/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	id := d.Id()
 
-	response, error := sling.New().Delete("consumers/").Path(id).ReceiveSuccess(nil)
+	if d.Get("force_destroy").(bool) {
+		for _, credentials := range consumerCredentials {
+			if err := deleteDependents(sling.Workspace(d.Get("workspace").(string)), sling.pageSize, "consumers/"+pathSegment(id)+"/"+credentials); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	response, error := sling.Workspace(d.Get("workspace").(string)).Delete("consumers/").Path(pathSegment(id)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting consumer")
+		return diag.Errorf("error while deleting consumer: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getConsumerFromResourceData(d *schema.ResourceData) *Consumer {
@@ -142,7 +203,7 @@ func getConsumerFromResourceData(d *schema.ResourceData) *Consumer {
 		ID:       d.Id(),
 		Username: d.Get("username").(string),
 		CustomID: d.Get("custom_id").(string),
-		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return consumer
@@ -153,4 +214,5 @@ func setConsumerToResourceData(d *schema.ResourceData, consumer *Consumer) {
 	d.Set("username", consumer.Username)
 	d.Set("custom_id", consumer.CustomID)
 	d.Set("tags", consumer.Tags)
+	d.Set("updated_at", consumer.UpdatedAt)
 }
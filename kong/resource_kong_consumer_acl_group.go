@@ -1,11 +1,12 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -16,14 +17,34 @@ type ConsumerACLGroup struct {
 	Tags     []string `json:"tags"`
 }
 
+// strictFields returns the consumer of the ACL group, which its path already gives.
+func (c *ConsumerACLGroup) strictFields() []string {
+	return []string{"consumer"}
+}
+
 func resourceKongConsumerACLGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongConsumerACLGroupCreate,
-		Read:   resourceKongConsumerACLGroupRead,
-		Update: resourceKongConsumerACLGroupUpdate,
-		Delete: resourceKongConsumerACLGroupDelete,
+		CreateContext: resourceKongConsumerACLGroupCreate,
+		ReadContext:   resourceKongConsumerACLGroupRead,
+		UpdateContext: resourceKongConsumerACLGroupUpdate,
+		DeleteContext: resourceKongConsumerACLGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportConsumerCredential,
+		},
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		CustomizeDiff: validateReferences(entityReference{attribute: "consumer", collection: "consumers", entity: "consumer"}),
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the ACL group belongs to. Defaults to the default workspace.",
+			},
+
 			"group": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -31,12 +52,13 @@ func resourceKongConsumerACLGroup() *schema.Resource {
 			},
 
 			"consumer": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUIDReference,
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
@@ -45,42 +67,42 @@ func resourceKongConsumerACLGroup() *schema.Resource {
 	}
 }
 
-func resourceKongConsumerACLGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
 	createdConsumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(consumerACLGroup).Path("consumers/").Path(consumerACLGroup.Consumer + "/").Post("acls/").ReceiveSuccess(createdConsumerACLGroup)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(consumerACLGroup).Path("consumers/").Path(pathSegment(consumerACLGroup.Consumer) + "/").Post("acls/").ReceiveSuccess(createdConsumerACLGroup)
 	if error != nil {
-		return fmt.Errorf("error while creating consumer ACL group")
+		return diag.Errorf("error while creating consumer ACL group: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setConsumerACLGroupToResourceData(d, createdConsumerACLGroup)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongConsumerACLGroupRead)
 }
 
-func resourceKongConsumerACLGroupRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(consumerACLGroup.Consumer + "/").Path("acls/").Get(consumerACLGroup.ID).ReceiveSuccess(consumerACLGroup)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "consumers/"+pathSegment(consumerACLGroup.Consumer)+"/acls/"+pathSegment(consumerACLGroup.ID), "acls", consumerACLGroup.ID, consumerACLGroup)
 	if error != nil {
-		return fmt.Errorf("error while updating consumer ACL group")
+		return diag.Errorf("error while reading consumer ACL group: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setConsumerACLGroupToResourceData(d, consumerACLGroup)
@@ -88,39 +110,39 @@ func resourceKongConsumerACLGroupRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceKongConsumerACLGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
 	updatedConsumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(consumerACLGroup).Path("consumers/").Path(consumerACLGroup.Consumer + "/").Patch("acls/").Path(consumerACLGroup.ID).ReceiveSuccess(updatedConsumerACLGroup)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(consumerACLGroup).Path("consumers/").Path(pathSegment(consumerACLGroup.Consumer) + "/").Patch("acls/").Path(pathSegment(consumerACLGroup.ID)).ReceiveSuccess(updatedConsumerACLGroup)
 	if error != nil {
-		return fmt.Errorf("error while updating consumer ACL group")
+		return diag.Errorf("error while updating consumer ACL group: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setConsumerACLGroupToResourceData(d, updatedConsumerACLGroup)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongConsumerACLGroupRead)
 }
 
-func resourceKongConsumerACLGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(consumerACLGroup.Consumer + "/").Path("acls/").Delete(consumerACLGroup.ID).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("consumers/").Path(pathSegment(consumerACLGroup.Consumer) + "/").Path("acls/").Delete(pathSegment(consumerACLGroup.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting consumer ACL group")
+		return diag.Errorf("error while deleting consumer ACL group: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
 	return nil
@@ -131,7 +153,7 @@ func getConsumerACLGroupFromResourceData(d *schema.ResourceData) *ConsumerACLGro
 		ID:       d.Id(),
 		Group:    d.Get("group").(string),
 		Consumer: d.Get("consumer").(string),
-		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return consumerACLGroup
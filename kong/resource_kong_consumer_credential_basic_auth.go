@@ -1,15 +1,17 @@
 package kong
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"crypto/sha1"
 	"io"
 	"strings"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -21,18 +23,34 @@ type BasicAuthCredential struct {
 	Tags     []string `json:"tags"`
 }
 
+// strictFields returns the consumer of the credential, which its path already gives.
+func (c *BasicAuthCredential) strictFields() []string {
+	return []string{"consumer"}
+}
+
 func resourceKongBasicAuthCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongBasicAuthCredentialCreate,
-		Read:   resourceKongBasicAuthCredentialRead,
-		Update: resourceKongBasicAuthCredentialUpdate,
-		Delete: resourceKongBasicAuthCredentialDelete,
+		CreateContext: resourceKongBasicAuthCredentialCreate,
+		ReadContext:   resourceKongBasicAuthCredentialRead,
+		UpdateContext: resourceKongBasicAuthCredentialUpdate,
+		DeleteContext: resourceKongBasicAuthCredentialDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		CustomizeDiff: validateReferences(entityReference{attribute: "consumer", collection: "consumers", entity: "consumer"}),
 
 		Importer: &schema.ResourceImporter{
-			State: ImportConsumerCredential,
+			StateContext: ImportConsumerCredential,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the credential belongs to. Defaults to the default workspace.",
+			},
+
 			"username": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -54,12 +72,13 @@ func resourceKongBasicAuthCredential() *schema.Resource {
 			},
 
 			"consumer": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUIDReference,
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
@@ -68,42 +87,42 @@ func resourceKongBasicAuthCredential() *schema.Resource {
 	}
 }
 
-func resourceKongBasicAuthCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
 	createdBasicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(basicAuthCredential).Path("consumers/").Path(basicAuthCredential.Consumer + "/").Post("basic-auth/").ReceiveSuccess(createdBasicAuthCredential)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(basicAuthCredential).Path("consumers/").Path(pathSegment(basicAuthCredential.Consumer) + "/").Post("basic-auth/").ReceiveSuccess(createdBasicAuthCredential)
 	if error != nil {
-		return fmt.Errorf("error while creating basicAuthCredential")
+		return diag.Errorf("error while creating basicAuthCredential: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setBasicAuthCredentialToResourceData(d, createdBasicAuthCredential)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongBasicAuthCredentialRead)
 }
 
-func resourceKongBasicAuthCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(basicAuthCredential.Consumer + "/").Path("basic-auth/").Get(basicAuthCredential.ID).ReceiveSuccess(basicAuthCredential)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "consumers/"+pathSegment(basicAuthCredential.Consumer)+"/basic-auth/"+pathSegment(basicAuthCredential.ID), "basic-auths", basicAuthCredential.ID, basicAuthCredential)
 	if error != nil {
-		return fmt.Errorf("error while updating basicAuthCredential")
+		return diag.Errorf("error while reading basicAuthCredential: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setBasicAuthCredentialToResourceData(d, basicAuthCredential)
@@ -111,39 +130,39 @@ func resourceKongBasicAuthCredentialRead(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceKongBasicAuthCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
 	updatedBasicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(basicAuthCredential).Path("consumers/").Path(basicAuthCredential.Consumer + "/").Patch("basic-auth/").Path(basicAuthCredential.ID).ReceiveSuccess(updatedBasicAuthCredential)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(basicAuthCredential).Path("consumers/").Path(pathSegment(basicAuthCredential.Consumer) + "/").Patch("basic-auth/").Path(pathSegment(basicAuthCredential.ID)).ReceiveSuccess(updatedBasicAuthCredential)
 	if error != nil {
-		return fmt.Errorf("error while updating basicAuthCredential")
+		return diag.Errorf("error while updating basicAuthCredential: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setBasicAuthCredentialToResourceData(d, updatedBasicAuthCredential)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongBasicAuthCredentialRead)
 }
 
-func resourceKongBasicAuthCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(basicAuthCredential.Consumer + "/").Path("basic-auth/").Delete(basicAuthCredential.ID).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("consumers/").Path(pathSegment(basicAuthCredential.Consumer) + "/").Path("basic-auth/").Delete(pathSegment(basicAuthCredential.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting basicAuthCredential")
+		return diag.Errorf("error while deleting basicAuthCredential: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
 	return nil
@@ -155,7 +174,7 @@ func getBasicAuthCredentialFromResourceData(d *schema.ResourceData) *BasicAuthCr
 		Username: d.Get("username").(string),
 		Password: d.Get("password").(string),
 		Consumer: d.Get("consumer").(string),
-		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return basicAuthCredential
@@ -1,12 +1,13 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -20,18 +21,34 @@ type JWTCredential struct {
 	Tags         []string `json:"tags"`
 }
 
+// strictFields returns the consumer of the credential, which its path already gives.
+func (c *JWTCredential) strictFields() []string {
+	return []string{"consumer"}
+}
+
 func resourceKongJWTCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongJWTCredentialCreate,
-		Read:   resourceKongJWTCredentialRead,
-		Update: resourceKongJWTCredentialUpdate,
-		Delete: resourceKongJWTCredentialDelete,
+		CreateContext: resourceKongJWTCredentialCreate,
+		ReadContext:   resourceKongJWTCredentialRead,
+		UpdateContext: resourceKongJWTCredentialUpdate,
+		DeleteContext: resourceKongJWTCredentialDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		CustomizeDiff: validateReferences(entityReference{attribute: "consumer", collection: "consumers", entity: "consumer"}),
 
 		Importer: &schema.ResourceImporter{
-			State: ImportConsumerCredential,
+			StateContext: ImportConsumerCredential,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the credential belongs to. Defaults to the default workspace.",
+			},
+
 			"key": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -41,10 +58,11 @@ func resourceKongJWTCredential() *schema.Resource {
 			},
 
 			"algorithm": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     nil,
-				Description: "The algorithm used to verify the token's signature. Can be HS256 or RS256.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          nil,
+				ValidateDiagFunc: validateOneOf(jwtAlgorithms...),
+				Description:      "The algorithm used to verify the token's signature, e.g. HS256 or RS256.",
 			},
 
 			"rsa_public_key": {
@@ -70,12 +88,13 @@ func resourceKongJWTCredential() *schema.Resource {
 			},
 
 			"consumer": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUIDReference,
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
@@ -84,42 +103,42 @@ func resourceKongJWTCredential() *schema.Resource {
 	}
 }
 
-func resourceKongJWTCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
 	createdJWTCredential := getJWTCredentialFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(jwtCredential).Path("consumers/").Path(jwtCredential.Consumer + "/").Post("jwt/").ReceiveSuccess(createdJWTCredential)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(jwtCredential).Path("consumers/").Path(pathSegment(jwtCredential.Consumer) + "/").Post("jwt/").ReceiveSuccess(createdJWTCredential)
 	if error != nil {
-		return fmt.Errorf("error while creating jwtCredential")
+		return diag.Errorf("error while creating jwtCredential: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setJWTCredentialToResourceData(d, createdJWTCredential)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongJWTCredentialRead)
 }
 
-func resourceKongJWTCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(jwtCredential.Consumer + "/").Path("jwt/").Get(jwtCredential.ID).ReceiveSuccess(jwtCredential)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "consumers/"+pathSegment(jwtCredential.Consumer)+"/jwt/"+pathSegment(jwtCredential.ID), "jwts", jwtCredential.ID, jwtCredential)
 	if error != nil {
-		return fmt.Errorf("error while updating jwtCredential")
+		return diag.Errorf("error while reading jwtCredential: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setJWTCredentialToResourceData(d, jwtCredential)
@@ -127,39 +146,39 @@ func resourceKongJWTCredentialRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceKongJWTCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
 	updatedJWTCredential := getJWTCredentialFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(jwtCredential).Path("consumers/").Path(jwtCredential.Consumer + "/").Patch("jwt/").Path(jwtCredential.ID).ReceiveSuccess(updatedJWTCredential)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(jwtCredential).Path("consumers/").Path(pathSegment(jwtCredential.Consumer) + "/").Patch("jwt/").Path(pathSegment(jwtCredential.ID)).ReceiveSuccess(updatedJWTCredential)
 	if error != nil {
-		return fmt.Errorf("error while updating jwtCredential")
+		return diag.Errorf("error while updating jwtCredential: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setJWTCredentialToResourceData(d, updatedJWTCredential)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongJWTCredentialRead)
 }
 
-func resourceKongJWTCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(jwtCredential.Consumer + "/").Path("jwt/").Delete(jwtCredential.ID).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("consumers/").Path(pathSegment(jwtCredential.Consumer) + "/").Path("jwt/").Delete(pathSegment(jwtCredential.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting jwtCredential")
+		return diag.Errorf("error while deleting jwtCredential: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
 	return nil
@@ -173,7 +192,7 @@ func getJWTCredentialFromResourceData(d *schema.ResourceData) *JWTCredential {
 		RSAPublicKey: d.Get("rsa_public_key").(string),
 		Secret:       d.Get("secret").(string),
 		Consumer:     d.Get("consumer").(string),
-		Tags:         helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:         helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return jwtCredential
@@ -1,11 +1,12 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,18 +18,34 @@ type KeyAuthCredential struct {
 	Tags     []string `json:"tags"`
 }
 
+// strictFields returns the consumer of the credential, which its path already gives.
+func (c *KeyAuthCredential) strictFields() []string {
+	return []string{"consumer"}
+}
+
 func resourceKongKeyAuthCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongKeyAuthCredentialCreate,
-		Read:   resourceKongKeyAuthCredentialRead,
-		Update: resourceKongKeyAuthCredentialUpdate,
-		Delete: resourceKongKeyAuthCredentialDelete,
+		CreateContext: resourceKongKeyAuthCredentialCreate,
+		ReadContext:   resourceKongKeyAuthCredentialRead,
+		UpdateContext: resourceKongKeyAuthCredentialUpdate,
+		DeleteContext: resourceKongKeyAuthCredentialDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		CustomizeDiff: validateReferences(entityReference{attribute: "consumer", collection: "consumers", entity: "consumer"}),
 
 		Importer: &schema.ResourceImporter{
-			State: ImportConsumerCredential,
+			StateContext: ImportConsumerCredential,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the credential belongs to. Defaults to the default workspace.",
+			},
+
 			"key": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -38,12 +55,13 @@ func resourceKongKeyAuthCredential() *schema.Resource {
 			},
 
 			"consumer": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUIDReference,
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
@@ -58,42 +76,42 @@ func resourceKongKeyAuthCredential() *schema.Resource {
 	}
 }
 
-func resourceKongKeyAuthCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
 	createdKeyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(keyAuthCredential).Path("consumers/").Path(keyAuthCredential.Consumer + "/").Post("key-auth/").ReceiveSuccess(createdKeyAuthCredential)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(keyAuthCredential).Path("consumers/").Path(pathSegment(keyAuthCredential.Consumer) + "/").Post("key-auth/").ReceiveSuccess(createdKeyAuthCredential)
 	if error != nil {
-		return fmt.Errorf("error while creating keyAuthCredential")
+		return diag.Errorf("error while creating keyAuthCredential: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setKeyAuthCredentialToResourceData(d, createdKeyAuthCredential)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongKeyAuthCredentialRead)
 }
 
-func resourceKongKeyAuthCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(keyAuthCredential.Consumer + "/").Path("key-auth/").Get(keyAuthCredential.ID).ReceiveSuccess(keyAuthCredential)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "consumers/"+pathSegment(keyAuthCredential.Consumer)+"/key-auth/"+pathSegment(keyAuthCredential.ID), "key-auths", keyAuthCredential.ID, keyAuthCredential)
 	if error != nil {
-		return fmt.Errorf("error while updating keyAuthCredential")
+		return diag.Errorf("error while reading keyAuthCredential: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setKeyAuthCredentialToResourceData(d, keyAuthCredential)
@@ -101,39 +119,39 @@ func resourceKongKeyAuthCredentialRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceKongKeyAuthCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
 	updatedKeyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(keyAuthCredential).Path("consumers/").Path(keyAuthCredential.Consumer + "/").Patch("key-auth/").Path(keyAuthCredential.ID).ReceiveSuccess(updatedKeyAuthCredential)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(keyAuthCredential).Path("consumers/").Path(pathSegment(keyAuthCredential.Consumer) + "/").Patch("key-auth/").Path(pathSegment(keyAuthCredential.ID)).ReceiveSuccess(updatedKeyAuthCredential)
 	if error != nil {
-		return fmt.Errorf("error while updating keyAuthCredential")
+		return diag.Errorf("error while updating keyAuthCredential: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setKeyAuthCredentialToResourceData(d, updatedKeyAuthCredential)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongKeyAuthCredentialRead)
 }
 
-func resourceKongKeyAuthCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(keyAuthCredential.Consumer + "/").Path("key-auth/").Delete(keyAuthCredential.ID).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("consumers/").Path(pathSegment(keyAuthCredential.Consumer) + "/").Path("key-auth/").Delete(pathSegment(keyAuthCredential.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting keyAuthCredential")
+		return diag.Errorf("error while deleting keyAuthCredential: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
 	return nil
@@ -144,7 +162,7 @@ func getKeyAuthCredentialFromResourceData(d *schema.ResourceData) *KeyAuthCreden
 		ID:       d.Id(),
 		Key:      d.Get("key").(string),
 		Consumer: d.Get("consumer").(string),
-		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 		TTL:      d.Get("ttl").(int),
 	}
 
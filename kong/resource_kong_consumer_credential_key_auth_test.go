@@ -0,0 +1,33 @@
+package kong
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceKongKeyAuthCredentialReadStrict(t *testing.T) {
+	kong := newFakeKong(t)
+	consumer := kong.put("consumers", map[string]interface{}{"username": "example"})
+	id := kong.put("key-auth", map[string]interface{}{
+		"key":      "secret",
+		"ttl":      nil,
+		"tags":     []interface{}{"team-a"},
+		"consumer": map[string]interface{}{"id": consumer},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceKongKeyAuthCredential().Schema, map[string]interface{}{
+		"consumer": consumer,
+	})
+	d.SetId(id)
+
+	expectNoError(t, resourceKongKeyAuthCredentialRead(context.Background(), d, kong.clientWith(Config{Strict: true})))
+
+	if key := d.Get("key").(string); key != "secret" {
+		t.Errorf("key is %q, want the key stored in Kong", key)
+	}
+	if d.Get("consumer").(string) != consumer {
+		t.Errorf("consumer is %q, want %q", d.Get("consumer"), consumer)
+	}
+}
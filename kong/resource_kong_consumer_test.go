@@ -0,0 +1,30 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceKongConsumerDecodeError(t *testing.T) {
+	kong := newFakeKong(t)
+	r := resourceKongConsumer()
+
+	kong.respond(http.MethodPost, "consumers", http.StatusCreated, "not a consumer")
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"username": "consumer"})
+	message := diagnosticsError(t, r.CreateContext(context.Background(), d, kong.client()))
+	if !strings.HasPrefix(message, "error while creating consumer: ") || !strings.Contains(message, "cannot unmarshal") {
+		t.Errorf("create failed with %q, want the decode error", message)
+	}
+
+	id := kong.put("consumers", map[string]interface{}{"username": "consumer"})
+	kong.respond(http.MethodGet, "consumers/"+id, http.StatusOK, "not a consumer")
+	d.SetId(id)
+	message = diagnosticsError(t, r.ReadContext(context.Background(), d, kong.client()))
+	if !strings.HasPrefix(message, "error while reading consumer: ") || !strings.Contains(message, "cannot unmarshal") {
+		t.Errorf("read failed with %q, want the decode error", message)
+	}
+}
@@ -0,0 +1,86 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongNodeCachePurge purges the cache of the Kong node when created, and again whenever triggers change, so
+// that cache invalidation can be ordered after the changes it depends on, e.g. credentials or certificates. Only the
+// node serving the Admin API is purged, the other nodes of a cluster relying on invalidation events.
+func resourceKongNodeCachePurge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKongNodeCachePurgeCreate,
+		ReadContext:   resourceKongNodeCachePurgeRead,
+		DeleteContext: resourceKongNodeCachePurgeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The cache key to purge. The whole cache is purged when not set.",
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values which purge the cache again when changed, e.g. the IDs of the credentials whose changes must be seen right away.",
+			},
+		},
+	}
+}
+
+func resourceKongNodeCachePurgeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	request := meta.(*Client).WithContext(ctx).New()
+
+	key := d.Get("key").(string)
+	if key != "" {
+		request = request.Path("cache/").Delete(pathSegment(key))
+	} else {
+		request = request.Delete("cache")
+	}
+
+	response, err := request.ReceiveSuccess(nil)
+	if err != nil {
+		return diag.Errorf("error while purging the cache: %v", err)
+	}
+
+	// A key missing from the cache has nothing to purge.
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	tflog.Info(ctx, "cache purged", map[string]interface{}{
+		"key": key,
+	})
+
+	// Every purge is a distinct resource, so that changing triggers replaces it.
+	d.SetId(helper.NameBasedUUID(fmt.Sprintf("cache-purge/%s/%d", key, time.Now().UnixNano())))
+
+	return resourceKongNodeCachePurgeRead(ctx, d, meta)
+}
+
+// resourceKongNodeCachePurgeRead has nothing to read, a purge leaving nothing behind in Kong.
+func resourceKongNodeCachePurgeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceKongNodeCachePurgeDelete only removes the purge from the state.
+func resourceKongNodeCachePurgeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceKongNodeCachePurgeNotWaitingForDataPlanes(t *testing.T) {
+	kong := newFakeKong(t)
+	kong.respond(http.MethodDelete, "cache", http.StatusNoContent, nil)
+
+	r := Provider().ResourcesMap["kong_node_cache_purge"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+
+	expectNoError(t, r.CreateContext(context.Background(), d, dataPlanesClient(kong)))
+	kong.lastRequestTo(http.MethodDelete, "cache")
+
+	if d.Id() == "" {
+		t.Error("ID not set after purging the cache")
+	}
+}
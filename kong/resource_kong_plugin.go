@@ -1,15 +1,22 @@
 package kong
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/agext/levenshtein"
 	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Plugin : Kong Service/API plugin request object structure
@@ -21,220 +28,801 @@ type Plugin struct {
 	Service       string                 `json:"-"`
 	Route         string                 `json:"-"`
 	Consumer      string                 `json:"-"`
+	ConsumerGroup string                 `json:"-"`
 	Tags          []string               `json:"tags"`
 	Enabled       bool                   `json:"enabled"`
+	CreatedAt     int                    `json:"created_at,omitempty"`
+	UpdatedAt     int                    `json:"updated_at,omitempty"`
+}
+
+// pluginReference : nested { "id": ... } object Kong uses to reference other entities, e.g. the ones a plugin is scoped to
+type pluginReference struct {
+	ID string `json:"id"`
+}
+
+// MarshalJSON sends the scope of the plugin as the nested { "id": ... } objects Kong expects. Unset scopes are sent
+// as null so that a PATCH moves the plugin off the entity it was previously attached to.
+func (p Plugin) MarshalJSON() ([]byte, error) {
+	type plugin Plugin
+
+	body := struct {
+		plugin
+		Service       *pluginReference `json:"service"`
+		Route         *pluginReference `json:"route"`
+		Consumer      *pluginReference `json:"consumer"`
+		ConsumerGroup *pluginReference `json:"consumer_group,omitempty"`
+	}{plugin: plugin(p)}
+
+	if p.Service != "" {
+		body.Service = &pluginReference{ID: p.Service}
+	}
+	if p.Route != "" {
+		body.Route = &pluginReference{ID: p.Route}
+	}
+	if p.Consumer != "" {
+		body.Consumer = &pluginReference{ID: p.Consumer}
+	}
+	if p.ConsumerGroup != "" {
+		body.ConsumerGroup = &pluginReference{ID: p.ConsumerGroup}
+	}
+
+	return json.Marshal(body)
+}
+
+// UnmarshalJSON decodes the scope of the plugin from the nested { "id": ... } objects returned by Kong 1.0 and up,
+// falling back to plain string IDs.
+func (p *Plugin) UnmarshalJSON(data []byte) error {
+	type plugin Plugin
+
+	body := struct {
+		*plugin
+		Service       json.RawMessage `json:"service"`
+		Route         json.RawMessage `json:"route"`
+		Consumer      json.RawMessage `json:"consumer"`
+		ConsumerGroup json.RawMessage `json:"consumer_group"`
+	}{plugin: (*plugin)(p)}
+
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	var err error
+	if p.Service, err = decodeReferenceID(body.Service); err != nil {
+		return err
+	}
+	if p.Route, err = decodeReferenceID(body.Route); err != nil {
+		return err
+	}
+	if p.Consumer, err = decodeReferenceID(body.Consumer); err != nil {
+		return err
+	}
+	if p.ConsumerGroup, err = decodeReferenceID(body.ConsumerGroup); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// strictFields returns the scope of the plugin, which UnmarshalJSON decodes.
+func (p *Plugin) strictFields() []string {
+	return []string{"service", "route", "consumer", "consumer_group"}
+}
+
+// decodeReferenceID returns the ID held by a reference that is either null, a string or a { "id": ... } object.
+func decodeReferenceID(data json.RawMessage) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		return id, nil
+	}
+
+	reference := &pluginReference{}
+	if err := json.Unmarshal(data, reference); err != nil {
+		return "", fmt.Errorf("unexpected entity reference %s: %v", data, err)
+	}
+
+	return reference.ID, nil
 }
 
 func resourceKongPlugin() *schema.Resource {
+	pluginSchema := pluginBaseSchema()
+
+	pluginSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Default:     nil,
+		Description: "The name of the plugin to use.",
+	}
+
+	pluginSchema["config_json"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringIsJSON,
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return jsonEqual(old, new)
+		},
+		Description: "The configuration properties for the plugin, encoded as JSON. Vault references such as {vault://env/my-secret} are kept verbatim.",
+	}
+
+	pluginSchema["config_changes"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Computed:    true,
+		Description: "A per-property summary of the latest change to config_json, one line per added (+), removed (-) or changed (~) property.",
+	}
+
 	return &schema.Resource{
-		Create: resourceKongPluginCreate,
-		Read:   resourceKongPluginRead,
-		Update: resourceKongPluginUpdate,
-		Delete: resourceKongPluginDelete,
+		CreateContext: pluginConfigJSON.create,
+		ReadContext:   pluginConfigJSON.read,
+		UpdateContext: pluginConfigJSON.update,
+		DeleteContext: resourceKongPluginDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importWorkspaceEntity("plugin"),
+		},
+
+		CustomizeDiff: customdiff.All(
+			validatePluginName,
+			validatePluginConfigJSON,
+			computeUpdatedAtOnChange,
+			computePluginConfigChanges,
+			validateReferences(pluginScopeReferences...),
+			preventProtectedReplacement("plugin", pluginForceNew...),
+		),
+
+		Schema: pluginSchema,
+	}
+}
+
+// pluginForceNew : attributes of pluginBaseSchema whose change replaces the plugin
+var pluginForceNew = []string{"consumer_group", "workspace"}
+
+// pluginBaseSchema returns the attributes shared by kong_plugin and the typed plugin resources.
+func pluginBaseSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"protocols": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validateOneOf(routeProtocols...)},
+			Optional:    true,
+			Computed:    true,
+			Description: "A list of the request protocols that will trigger this plugin. Defaults to the protocols supported by the plugin.",
+		},
+
+		"service": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Default:       nil,
+			ConflictsWith: []string{"route", "consumer", "consumer_group"},
+			ValidateFunc:  validation.All(validatePluginScope, validateUUIDReference),
+			Description:   "The id of the service to scope this plugin to. If set, the plugin will only activate when receiving requests via one of the routes belonging to the specified Service. Changing it moves the plugin in place.",
+		},
+
+		"route": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Default:       nil,
+			ConflictsWith: []string{"service", "consumer", "consumer_group"},
+			ValidateFunc:  validation.All(validatePluginScope, validateUUIDReference),
+			Description:   "The id of the route to scope this plugin to. If set, the plugin will only activate when receiving requests via the specified route. Changing it moves the plugin in place.",
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Default:     nil,
-				Description: "The name of the plugin to use.",
-			},
-
-			"protocols": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Required:    true,
-				Default:     nil,
-				Description: "A list of the request protocols that will trigger this plugin",
-			},
-
-			"config_json": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  nil,
-			},
-
-			"service": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Default:       nil,
-				ConflictsWith: []string{"route", "consumer"},
-				Description:   "The id of the route to scope this plugin to. f set, the plugin will only activate when receiving requests via one of the routes belonging to the specified Service",
-			},
-
-			"route": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Default:       nil,
-				ConflictsWith: []string{"service", "consumer"},
-				Description:   "The id of the route to scope this plugin to. If set, the plugin will only activate when receiving requests via the specified route",
-			},
-
-			"consumer": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Default:       nil,
-				ConflictsWith: []string{"service", "route"},
-				Description:   "The id of the consumer to scope this plugin to. If set, the plugin will activate only for requests where the specified has been authenticated",
-			},
-
-			"tags": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Description: "An optional set of strings associated with the Service for grouping and filtering.",
-			},
-
-			"enabled": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Description: "Whether the Service is active",
-				Default:     true,
-			},
+		"consumer": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Default:       nil,
+			ConflictsWith: []string{"service", "route", "consumer_group"},
+			ValidateFunc:  validation.All(validatePluginScope, validateUUIDReference),
+			Description:   "The id of the consumer to scope this plugin to. If set, the plugin will activate only for requests where the specified has been authenticated. Changing it moves the plugin in place.",
+		},
+
+		"consumer_group": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"service", "route", "consumer"},
+			ValidateFunc:  validation.All(validatePluginScope, validateUUIDReference),
+			Description:   "The id of the consumer group to scope this plugin to (Kong Enterprise 3.4 and up). When none of service, route, consumer and consumer_group is set, the plugin is applied globally.",
+		},
+
+		"workspace": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The Kong Enterprise workspace the plugin belongs to. Defaults to the default workspace.",
+		},
+
+		"tags": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "An optional set of strings associated with the Service for grouping and filtering.",
+		},
+
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether the Service is active",
+			Default:     true,
+		},
+
+		"created_at": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Unix epoch when the plugin was created.",
+		},
+
+		"updated_at": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Unix epoch when the plugin was last updated.",
+		},
+
+		"upsert": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Create and update the plugin with PUT using an ID derived from its name and scope, so that retrying a partially failed apply never conflicts with the plugin it already created.",
+		},
+
+		"adopt_on_conflict": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "When Kong already has a plugin with the same name and scope, take it over instead of failing with 409 Conflict.",
+		},
+
+		"protect": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to refuse deleting or replacing the plugin, e.g. on terraform destroy, until protect is set back to false and applied.",
 		},
 	}
 }
 
-func resourceKongPluginCreate(d *schema.ResourceData, meta interface{}) error {
-	request := buildModifyRequest(d, meta)
+// pluginConfig : how a plugin resource maps its name and config attributes to the Kong plugin object
+type pluginConfig struct {
+	name    func(d *schema.ResourceData) string
+	expand  func(d *schema.ResourceData) (map[string]interface{}, error)
+	flatten func(d *schema.ResourceData, plugin *Plugin) error
+}
+
+// pluginConfigJSON : kong_plugin, where the plugin name and its JSON encoded config are plain attributes
+var pluginConfigJSON = pluginConfig{
+	name: func(d *schema.ResourceData) string {
+		return d.Get("name").(string)
+	},
+
+	expand: func(d *schema.ResourceData) (map[string]interface{}, error) {
+		c, ok := d.GetOk("config_json")
+		if !ok {
+			return nil, nil
+		}
+
+		config := make(map[string]interface{})
+		err := json.Unmarshal([]byte(c.(string)), &config)
+		if err != nil {
+			return nil, fmt.Errorf("config_json is not a valid JSON object: %v", err)
+		}
+
+		return config, nil
+	},
+
+	flatten: func(d *schema.ResourceData, plugin *Plugin) error {
+		_ = d.Set("name", plugin.Name)
+
+		// Only the properties managed through config_json are read back, Kong fills in defaults for all the others.
+		// Without config_json, e.g. after an import, the complete config is read so that it can be generated.
+		var config interface{} = withoutNullConfig(plugin.Configuration)
+		if c, ok := d.GetOk("config_json"); ok {
+			desired := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(c.(string)), &desired); err != nil {
+				return nil
+			}
+			config = mergePluginConfig(desired, plugin.Configuration)
+		}
+
+		encoded, err := json.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("error while encoding plugin config: %v", err)
+		}
+		_ = d.Set("config_json", string(encoded))
+
+		return nil
+	},
+}
+
+func (pc pluginConfig) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).WithContext(ctx)
+
+	request, err := buildModifyRequest(d, client, pc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	p := &Plugin{}
 
-	if service, ok := d.GetOk("service"); ok {
-		request = request.Path("services/").Path(service.(string) + "/")
-	} else if route, ok := d.GetOk("route"); ok {
-		request = request.Path("routes/").Path(route.(string) + "/")
-	} else if consumer, ok := d.GetOk("consumer"); ok {
-		request = request.Path("consumers/").Path(consumer.(string) + "/")
+	if d.Get("upsert").(bool) {
+		if err := upsertPlugin(d, request, pluginUpsertID(d, pc.name(d)), pc); err != nil {
+			return diag.FromErr(err)
+		}
+		return readAfterWrite(ctx, d, meta, pc.read)
 	}
 
 	response, err := request.Post("plugins/").ReceiveSuccess(p)
 	if err != nil {
-		return fmt.Errorf("error while creating plugin: " + err.Error())
+		return diag.Errorf("error while creating plugin: %s", err.Error())
 	}
 
 	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this plugin")
+		if d.Get("adopt_on_conflict").(bool) {
+			return adoptExistingPlugin(ctx, d, client, pc)
+		}
+		return diag.Errorf("409 Conflict - use terraform import or set adopt_on_conflict to manage this plugin")
 	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	if err := setPluginToResourceData(d, p, pc); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readAfterWrite(ctx, d, meta, pc.read)
+}
+
+// validatePluginName fails the plan when the plugin is not enabled on the Kong node, suggesting similar names.
+func validatePluginName(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("name") || !d.NewValueKnown("name") {
+		return nil
+	}
+
+	client, ok := meta.(*Client)
+	if !ok {
+		return nil
+	}
+
+	// Nodes that do not expose the enabled plugins (e.g. restricted admin APIs) are left to fail at apply.
+	enabled, err := client.WithContext(ctx).EnabledPlugins()
+	if err != nil || len(enabled) == 0 {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+
+	var similar []string
+	for _, plugin := range enabled {
+		if plugin == name {
+			return nil
+		}
+
+		if levenshtein.Distance(plugin, name, nil) <= 3 || strings.Contains(plugin, name) || strings.Contains(name, plugin) {
+			similar = append(similar, plugin)
+		}
 	}
 
-	return setPluginToResourceData(d, p)
+	if len(similar) == 0 {
+		return fmt.Errorf("plugin %q is not enabled on the Kong node", name)
+	}
+
+	sort.Strings(similar)
+
+	return fmt.Errorf("plugin %q is not enabled on the Kong node, did you mean one of: %s", name, strings.Join(similar, ", "))
 }
 
-func resourceKongPluginRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+// validatePluginConfigJSON rejects config_json properties which are not part of the schema of the plugin, so that
+// typos fail the plan instead of the apply. Plugins unknown to the Kong node are left to validatePluginName.
+func validatePluginConfigJSON(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChanges("name", "config_json") || !d.NewValueKnown("name") || !d.NewValueKnown("config_json") {
+		return nil
+	}
+
+	client, ok := meta.(*Client)
+	if !ok {
+		return nil
+	}
+
+	config := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(d.Get("config_json").(string)), &config); err != nil || len(config) == 0 {
+		return nil
+	}
+
+	// Nodes that do not expose the plugin schemas are left to fail at apply.
+	name := d.Get("name").(string)
+	properties, found, err := client.WithContext(ctx).PluginConfigProperties(name)
+	if err != nil || !found || len(properties) == 0 {
+		return nil
+	}
+
+	known := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		known[p] = true
+	}
+
+	var unknown []string
+	for k := range config {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sorted := append([]string(nil), properties...)
+	sort.Strings(sorted)
+	sort.Strings(unknown)
+
+	return fmt.Errorf("config_json has properties unknown to plugin %q: %s. Its properties are: %s",
+		name, strings.Join(unknown, ", "), strings.Join(sorted, ", "))
+}
+
+// validatePluginScope rejects empty scope references, which would otherwise silently turn the plugin global.
+func validatePluginScope(v interface{}, k string) ([]string, []error) {
+	if v.(string) == "" {
+		return nil, []error{fmt.Errorf("%q must not be empty, omit service, route, consumer and consumer_group to apply the plugin globally", k)}
+	}
+
+	return nil, nil
+}
+
+// computeUpdatedAtOnChange marks updated_at as unknown whenever the plan is going to modify the entity.
+func computeUpdatedAtOnChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+
+	return d.SetNewComputed("updated_at")
+}
+
+// computePluginConfigChanges renders the change to config_json property by property, so that large configurations can
+// be reviewed in the plan instead of as a single JSON string.
+func computePluginConfigChanges(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("config_json") {
+		return nil
+	}
+
+	if !d.NewValueKnown("config_json") {
+		return d.SetNewComputed("config_changes")
+	}
+
+	o, n := d.GetChange("config_json")
+
+	oldConfig := make(map[string]interface{})
+	_ = json.Unmarshal([]byte(o.(string)), &oldConfig)
+
+	newConfig := make(map[string]interface{})
+	_ = json.Unmarshal([]byte(n.(string)), &newConfig)
+
+	return d.SetNew("config_changes", diffPluginConfig("", oldConfig, newConfig))
+}
+
+// diffPluginConfig lists the properties that differ between both configs, using dotted paths for nested objects.
+func diffPluginConfig(prefix string, oldConfig, newConfig map[string]interface{}) []string {
+	keys := make(map[string]bool)
+	for k := range oldConfig {
+		keys[k] = true
+	}
+	for k := range newConfig {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, k := range sorted {
+		path := prefix + k
+		ov, inOld := oldConfig[k]
+		nv, inNew := newConfig[k]
+
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("+ %s = %s", path, encodeConfigValue(nv)))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("- %s", path))
+		case !reflect.DeepEqual(ov, nv):
+			om, oIsMap := ov.(map[string]interface{})
+			nm, nIsMap := nv.(map[string]interface{})
+			if oIsMap && nIsMap {
+				changes = append(changes, diffPluginConfig(path+".", om, nm)...)
+			} else {
+				changes = append(changes, fmt.Sprintf("~ %s: %s => %s", path, encodeConfigValue(ov), encodeConfigValue(nv)))
+			}
+		}
+	}
+
+	return changes
+}
+
+func encodeConfigValue(v interface{}) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(encoded)
+}
+
+// upsertPlugin creates or replaces the plugin with the given ID through PUT.
+func upsertPlugin(d *schema.ResourceData, request *sling.Sling, id string, pc pluginConfig) error {
+	p := &Plugin{}
+
+	response, err := request.Path("plugins/").Put(pathSegment(id)).ReceiveSuccess(p)
+	if err != nil {
+		return fmt.Errorf("error while upserting plugin: %v", err)
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	return setPluginToResourceData(d, p, pc)
+}
+
+// pluginUpsertID derives the plugin ID from the name and scope, which is what Kong keeps unique for plugins.
+func pluginUpsertID(d *schema.ResourceData, name string) string {
+	return helper.NameBasedUUID(strings.Join([]string{
+		"plugin",
+		name,
+		d.Get("service").(string),
+		d.Get("route").(string),
+		d.Get("consumer").(string),
+		d.Get("consumer_group").(string),
+		d.Get("workspace").(string),
+	}, "/"))
+}
+
+// adoptExistingPlugin looks up the plugin that caused the conflict and takes it over by updating it in place.
+func adoptExistingPlugin(ctx context.Context, d *schema.ResourceData, meta interface{}, pc pluginConfig) diag.Diagnostics {
+	id, err := findPluginID(d, meta, pc.name(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+
+	return pc.update(ctx, d, meta)
+}
+
+// findPluginID returns the ID of the plugin having the same name and scope as the resource.
+func findPluginID(d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	client := meta.(*Client)
+	request := client.Workspace(d.Get("workspace").(string))
+
+	service := d.Get("service").(string)
+	route := d.Get("route").(string)
+	consumer := d.Get("consumer").(string)
+	consumerGroup := d.Get("consumer_group").(string)
+
+	if service != "" {
+		request = request.Path("services/").Path(pathSegment(service) + "/")
+	} else if route != "" {
+		request = request.Path("routes/").Path(pathSegment(route) + "/")
+	} else if consumer != "" {
+		request = request.Path("consumers/").Path(pathSegment(consumer) + "/")
+	} else if consumerGroup != "" {
+		request = request.Path("consumer_groups/").Path(pathSegment(consumerGroup) + "/")
+	}
+
+	var plugins []Plugin
+
+	found, err := listAll(request, client.pageSize, "plugins", &plugins)
+	if err != nil {
+		return "", fmt.Errorf("error while looking up existing plugin: %v", err)
+	}
+
+	if !found {
+		return "", fmt.Errorf("409 Conflict - the scope of the %q plugin was not found", name)
+	}
+
+	for _, p := range plugins {
+		if p.Name == name && p.Service == service && p.Route == route && p.Consumer == consumer && p.ConsumerGroup == consumerGroup {
+			return p.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("409 Conflict - no existing %q plugin found with the same scope to adopt", name)
+}
+
+func (pc pluginConfig) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	p := &Plugin{}
 
-	response, err := sling.New().Path("plugins/").Get(d.Id()).ReceiveSuccess(p)
+	response, err := sling.readEntity(ctx, d.Get("workspace").(string), "plugins/"+pathSegment(d.Id()), "plugins", d.Id(), p)
 	if err != nil {
-		return fmt.Errorf("error while updating plugin: " + err.Error())
+		return diag.Errorf("error while updating plugin: %s", err.Error())
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
-	return setPluginToResourceData(d, p)
+	return diag.FromErr(setPluginToResourceData(d, p, pc))
 }
 
-func resourceKongPluginUpdate(d *schema.ResourceData, meta interface{}) error {
-	request := buildModifyRequest(d, meta)
+func (pc pluginConfig) update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, client, "plugin", "plugins/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
+
+	request, err := buildModifyRequest(d, client, pc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("upsert").(bool) {
+		if err := upsertPlugin(d, request, d.Id(), pc); err != nil {
+			return diag.FromErr(err)
+		}
+		return append(diags, readAfterWrite(ctx, d, meta, pc.read)...)
+	}
 
 	p := &Plugin{}
 
-	response, err := request.Path("plugins/").Patch(d.Id()).ReceiveSuccess(p)
+	response, err := request.Path("plugins/").Patch(pathSegment(d.Id())).ReceiveSuccess(p)
 	if err != nil {
-		return fmt.Errorf("error while updating plugin: " + err.Error())
+		return diag.Errorf("error while updating plugin: %s", err.Error())
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	if err := setPluginToResourceData(d, p, pc); err != nil {
+		return diag.FromErr(err)
 	}
 
-	return setPluginToResourceData(d, p)
+	return append(diags, readAfterWrite(ctx, d, meta, pc.read)...)
 }
 
-func resourceKongPluginDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongPluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := checkNotProtected(d, "plugin"); diags.HasError() {
+		return diags
+	}
+
+	sling := meta.(*Client).WithContext(ctx)
 
-	response, error := sling.New().Path("plugins/").Delete(d.Id()).ReceiveSuccess(nil)
+	diags := checkConcurrentChanges(d, sling, "plugin", "plugins/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
+
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("plugins/").Delete(pathSegment(d.Id())).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting plugin: " + error.Error())
+		return diag.Errorf("error while deleting plugin: %s", error.Error())
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
-func buildModifyRequest(d *schema.ResourceData, meta interface{}) *sling.Sling {
-	request := meta.(*sling.Sling).New()
+// buildModifyRequest returns a request carrying the complete plugin object as JSON, so that creates and updates
+// never drop attributes that are not part of the configuration.
+func buildModifyRequest(d *schema.ResourceData, meta interface{}, pc pluginConfig) (*sling.Sling, error) {
+	request := meta.(*Client).Workspace(d.Get("workspace").(string))
+
+	config, err := pc.expand(d)
+	if err != nil {
+		return nil, err
+	}
 
 	plugin := &Plugin{
-		ID:        d.Id(),
-		Name:      d.Get("name").(string),
-		Protocols: helper.ConvertInterfaceArrToStrings(d.Get("protocols").([]interface{})),
-		Service:   d.Get("service").(string),
-		Route:     d.Get("route").(string),
-		Consumer:  d.Get("consumer").(string),
-		Tags:      helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
-		Enabled:   d.Get("enabled").(bool),
+		ID:            d.Id(),
+		Name:          pc.name(d),
+		Configuration: config,
+		Protocols:     helper.ConvertInterfaceArrToStrings(d.Get("protocols").(*schema.Set).List()),
+		Service:       d.Get("service").(string),
+		Route:         d.Get("route").(string),
+		Consumer:      d.Get("consumer").(string),
+		ConsumerGroup: d.Get("consumer_group").(string),
+		Tags:          helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
+		Enabled:       d.Get("enabled").(bool),
 	}
 
-	if c, ok := d.GetOk("config_json"); ok {
-		config := make(map[string]interface{})
-		err := json.Unmarshal([]byte(c.(string)), &config)
-		if err != nil {
-			fmt.Printf("JSON is invalid")
-		}
+	return request.BodyJSON(plugin), nil
+}
 
-		plugin.Configuration = config
+func setPluginToResourceData(d *schema.ResourceData, plugin *Plugin, pc pluginConfig) error {
+	d.SetId(plugin.ID)
 
-		request = request.BodyJSON(plugin)
-	} else {
-		form := url.Values{
-			"name": {plugin.Name},
-		}
+	_ = d.Set("protocols", plugin.Protocols)
+	_ = d.Set("service", plugin.Service)
+	_ = d.Set("route", plugin.Route)
+	_ = d.Set("consumer", plugin.Consumer)
+	_ = d.Set("consumer_group", plugin.ConsumerGroup)
+	_ = d.Set("tags", plugin.Tags)
+	_ = d.Set("enabled", plugin.Enabled)
+	_ = d.Set("created_at", plugin.CreatedAt)
+	_ = d.Set("updated_at", plugin.UpdatedAt)
 
-		body := strings.NewReader(form.Encode())
+	return pc.flatten(d, plugin)
+}
 
-		request = request.Body(body).Set("Content-Type", "application/x-www-form-urlencoded")
+// withoutNullConfig returns the config without the properties Kong leaves null, which are unset.
+func withoutNullConfig(config map[string]interface{}) map[string]interface{} {
+	cleaned := make(map[string]interface{}, len(config))
+	for k, v := range config {
+		switch value := v.(type) {
+		case nil:
+		case map[string]interface{}:
+			cleaned[k] = withoutNullConfig(value)
+		default:
+			cleaned[k] = value
+		}
 	}
 
-	return request
+	return cleaned
 }
 
-func setPluginToResourceData(d *schema.ResourceData, plugin *Plugin) error {
-	d.SetId(plugin.ID)
+// mergePluginConfig returns the actual config restricted to the properties of the desired one. Vault references are
+// preserved as written, since Kong may return them resolved or encrypted depending on its version. Desired properties
+// Kong doesn't return are left out, so that they show as drift.
+func mergePluginConfig(desired, actual interface{}) interface{} {
+	switch d := desired.(type) {
+	case string:
+		if isVaultReference(d) {
+			return d
+		}
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
 
-	_ = d.Set("name", plugin.Name)
+		merged := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			if av, ok := a[k]; ok {
+				merged[k] = mergePluginConfig(v, av)
+			}
+		}
+		return merged
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(d) {
+			break
+		}
 
-	// There are differences in the way service/route IDs are returned from Kong after creation and update between
-	// version before and after 1.0.0. We are risking some drift here. This will be handled in later versions.
-	if service, ok := d.GetOk("service"); ok {
-		plugin.Service = service.(string)
-	} else if route, ok := d.GetOk("route"); ok {
-		plugin.Route = route.(string)
-	} else if consumer, ok := d.GetOk("consumer"); ok {
-		plugin.Consumer = consumer.(string)
+		merged := make([]interface{}, len(d))
+		for i := range d {
+			merged[i] = mergePluginConfig(d[i], a[i])
+		}
+		return merged
 	}
 
-	_ = d.Set("protocols", plugin.Protocols)
-	_ = d.Set("service", plugin.Service)
-	_ = d.Set("route", plugin.Route)
-	_ = d.Set("consumer", plugin.Consumer)
-	_ = d.Set("tags", plugin.Tags)
-	_ = d.Set("enabled", plugin.Enabled)
+	return actual
+}
 
-	return nil
+// isVaultReference reports whether the value is a Kong vault reference like {vault://env/my-secret}.
+func isVaultReference(value string) bool {
+	return strings.HasPrefix(value, "{vault://") && strings.HasSuffix(value, "}")
+}
+
+// jsonEqual reports whether both strings hold the same JSON document, ignoring formatting and key order.
+func jsonEqual(a, b string) bool {
+	var av, bv interface{}
+	if json.Unmarshal([]byte(a), &av) != nil || json.Unmarshal([]byte(b), &bv) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(av, bv)
 }
@@ -0,0 +1,48 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginACL() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "acl",
+		ConfigRequired: true,
+		ToKong:         clearUnsetPluginConfig("allow", "deny"),
+
+		Config: map[string]*schema.Schema{
+			"allow": {
+				Type:         schema.TypeSet,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"config.0.allow", "config.0.deny"},
+				Description:  "ACL groups allowed to use the service or route. Conflicts with deny.",
+			},
+
+			"deny": {
+				Type:         schema.TypeSet,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"config.0.allow", "config.0.deny"},
+				Description:  "ACL groups denied from using the service or route. Conflicts with allow.",
+			},
+
+			"hide_groups_header": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to hide the X-Consumer-Groups header from the upstream service.",
+			},
+
+			"include_consumer_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether consumer groups are matched along with the ACL groups of the consumer.",
+			},
+		},
+	})
+}
@@ -0,0 +1,340 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginACME() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "acme",
+		ConfigRequired: true,
+		CustomizeDiff:  validateACMEScope,
+
+		Config: map[string]*schema.Schema{
+			"account_email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Email of the ACME account.",
+			},
+
+			"api_uri": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "https://acme-v02.api.letsencrypt.org/directory",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Directory URI of the ACME server, e.g. the Let's Encrypt staging directory for testing.",
+			},
+
+			"tos_accepted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the terms of service of the ACME server are accepted, required by Let's Encrypt.",
+			},
+
+			"domains": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Description: "Domains certificates are issued for, wildcards such as *.example.com matching the subdomains.",
+			},
+
+			"allow_any_domain": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether certificates are issued for any domain, ignoring domains.",
+			},
+
+			"cert_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rsa",
+				ValidateFunc: validation.StringInSlice([]string{"rsa", "ecc"}, false),
+				Description:  "The type of the key of the certificates.",
+			},
+
+			"rsa_key_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4096,
+				ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+				Description:  "Size of the RSA keys.",
+			},
+
+			"renew_threshold_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      14,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Days before expiry certificates are renewed.",
+			},
+
+			"fail_backoff_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minutes to wait before retrying a failed issuance of a domain.",
+			},
+
+			"eab_kid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key identifier of the external account binding, for ACME servers requiring it.",
+			},
+
+			"eab_hmac_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "HMAC key of the external account binding.",
+			},
+
+			"preferred_chain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Common name of the root of the certificate chain preferred, when the ACME server offers several.",
+			},
+
+			"storage": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "shm",
+				ValidateFunc: validation.StringInSlice([]string{"shm", "kong", "redis", "consul", "vault"}, false),
+				Description:  "Where certificates and challenges are stored. shm is only suitable for single node deployments.",
+			},
+
+			"storage_config": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Computed:    true,
+				Description: "Settings of the storage backends.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"shm":    acmeStorageSchema(acmeShmStorageSchema()),
+						"redis":  acmeStorageSchema(acmeRedisStorageSchema()),
+						"consul": acmeStorageSchema(acmeConsulStorageSchema()),
+						"vault":  acmeStorageSchema(acmeVaultStorageSchema()),
+					},
+				},
+			},
+		},
+	})
+}
+
+func acmeStorageSchema(fields map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Computed: true,
+		Elem:     &schema.Resource{Schema: fields},
+	}
+}
+
+func acmeShmStorageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"shm_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The shared dictionary of the Kong nodes storing the certificates.",
+		},
+	}
+}
+
+func acmeRedisStorageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Host of the redis server.",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port of the redis server.",
+		},
+		"database": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Database to use for the redis connection.",
+		},
+		"auth": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Password for redis authentication.",
+		},
+		"ssl": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to connect to redis over TLS.",
+		},
+		"ssl_verify": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to verify the certificate of the redis server.",
+		},
+		"ssl_server_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The server name used for SNI when connecting over TLS.",
+		},
+		"namespace": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Prefix of the keys, to share a database between several plugins.",
+		},
+	}
+}
+
+func acmeConsulStorageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Host of the Consul server.",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port of the Consul server.",
+		},
+		"https": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to connect to Consul over https.",
+		},
+		"timeout": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Timeout in milliseconds of the requests to Consul.",
+		},
+		"kv_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Path of the KV store.",
+		},
+		"token": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "ACL token of Consul.",
+		},
+	}
+}
+
+func acmeVaultStorageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Host of the Vault server.",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port of the Vault server.",
+		},
+		"https": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to connect to Vault over https.",
+		},
+		"timeout": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Timeout in milliseconds of the requests to Vault.",
+		},
+		"kv_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Path of the KV v1 secrets engine.",
+		},
+		"token": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Token of Vault, for the token auth method.",
+		},
+		"tls_verify": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to verify the certificate of the Vault server.",
+		},
+		"tls_server_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The server name used for SNI when connecting over TLS.",
+		},
+		"auth_method": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"token", "kubernetes"}, false),
+			Description:  "How Kong authenticates to Vault.",
+		},
+		"auth_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Mount path of the kubernetes auth method.",
+		},
+		"auth_role": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Role of the kubernetes auth method.",
+		},
+		"jwt_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Path of the service account token, for the kubernetes auth method.",
+		},
+	}
+}
+
+// validateACMEScope checks the plugin is applied globally, the only scope supported by Kong.
+func validateACMEScope(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, k := range []string{"service", "route", "consumer", "consumer_group"} {
+		if v, ok := d.GetOk(k); ok && v.(string) != "" {
+			return fmt.Errorf("the acme plugin can only be applied globally, %s must not be set", k)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginAIPromptGuard() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "ai-prompt-guard",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"allow_patterns": {
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringLenBetween(1, 500)},
+				Optional:     true,
+				MaxItems:     10,
+				AtLeastOneOf: []string{"config.0.allow_patterns", "config.0.deny_patterns"},
+				Description:  "PCRE regular expressions prompts must match, any prompt being allowed when empty. Their syntax is checked by Kong on apply.",
+			},
+
+			"deny_patterns": {
+				Type:         schema.TypeList,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringLenBetween(1, 500)},
+				Optional:     true,
+				MaxItems:     10,
+				AtLeastOneOf: []string{"config.0.allow_patterns", "config.0.deny_patterns"},
+				Description:  "PCRE regular expressions prompts must not match, taking precedence over allow_patterns. Their syntax is checked by Kong on apply.",
+			},
+
+			"allow_all_conversation_history": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check every message of the conversation rather than only the last user prompt.",
+			},
+
+			"match_all_roles": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to check the messages of every role rather than only the user messages.",
+			},
+
+			"max_request_body_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum size in bytes of the request bodies inspected.",
+			},
+		},
+	})
+}
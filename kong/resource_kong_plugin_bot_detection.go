@@ -0,0 +1,31 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginBotDetection() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:   "bot-detection",
+		ToKong: clearUnsetPluginConfig("allow", "deny"),
+
+		Config: map[string]*schema.Schema{
+			"allow": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsValidRegExp},
+				Optional:    true,
+				Computed:    true,
+				Description: "Regular expressions of User-Agent headers always allowed, taking precedence over the deny list and the known bots.",
+			},
+
+			"deny": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsValidRegExp},
+				Optional:    true,
+				Computed:    true,
+				Description: "Regular expressions of User-Agent headers denied, on top of the known bots.",
+			},
+		},
+	})
+}
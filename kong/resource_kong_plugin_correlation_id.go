@@ -0,0 +1,37 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginCorrelationID() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "correlation-id",
+
+		Config: map[string]*schema.Schema{
+			"header_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Kong-Request-ID",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The header holding the correlation ID.",
+			},
+
+			"generator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "uuid#counter",
+				ValidateFunc: validation.StringInSlice([]string{"uuid", "uuid#counter", "tracker"}, false),
+				Description:  "How correlation IDs are generated, among uuid, uuid#counter and tracker.",
+			},
+
+			"echo_downstream": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to send the correlation ID back to the client in the response.",
+			},
+		},
+	})
+}
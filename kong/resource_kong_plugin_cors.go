@@ -0,0 +1,98 @@
+package kong
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginCORS() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "cors",
+
+		Config: map[string]*schema.Schema{
+			"origins": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCORSOrigin},
+				Optional:    true,
+				Computed:    true,
+				Description: "Allowed origins, either *, exact origins such as https://example.com or regular expressions. All origins are allowed when empty.",
+			},
+
+			"methods": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validateOneOf(httpMethods...)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Value of the Access-Control-Allow-Methods header.",
+			},
+
+			"headers": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Value of the Access-Control-Allow-Headers header, defaults to the headers requested by the client.",
+			},
+
+			"exposed_headers": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Value of the Access-Control-Expose-Headers header.",
+			},
+
+			"credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to send the Access-Control-Allow-Credentials header.",
+			},
+
+			"max_age": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How long in seconds preflight results can be cached by clients.",
+			},
+
+			"preflight_continue": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to proxy the OPTIONS preflight requests to the upstream service.",
+			},
+
+			"private_network": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to send the Access-Control-Allow-Private-Network header.",
+			},
+		},
+	})
+}
+
+// validateCORSOrigin accepts * and exact origins, and otherwise checks the origin is a valid regular expression. Kong
+// uses PCRE, only the syntax common with Go regular expressions is checked.
+func validateCORSOrigin(v interface{}, k string) ([]string, []error) {
+	origin := v.(string)
+
+	if origin == "" {
+		return nil, []error{fmt.Errorf("%q must not be empty", k)}
+	}
+
+	if origin == "*" {
+		return nil, nil
+	}
+
+	if _, err := regexp.Compile(origin); err != nil {
+		return nil, []error{fmt.Errorf("%q must be *, an origin or a valid regular expression, got %q: %s", k, origin, err)}
+	}
+
+	return nil, nil
+}
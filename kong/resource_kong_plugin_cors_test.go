@@ -0,0 +1,40 @@
+package kong
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceKongPluginCORSReorderedLists(t *testing.T) {
+	kong := newFakeKong(t)
+	r := resourceKongPluginCORS()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"config": []interface{}{map[string]interface{}{
+			"origins":         []interface{}{"https://a.example.com", "https://b.example.com"},
+			"headers":         []interface{}{"Accept", "Authorization"},
+			"exposed_headers": []interface{}{"X-A", "X-B"},
+		}},
+	})
+
+	expectNoError(t, r.CreateContext(context.Background(), d, kong.client()))
+
+	config := kong.get("plugins", d.Id())["config"].(map[string]interface{})
+	config["origins"] = []interface{}{"https://b.example.com", "https://a.example.com"}
+	config["headers"] = []interface{}{"Authorization", "Accept"}
+	config["exposed_headers"] = []interface{}{"X-B", "X-A"}
+
+	before := map[string]*schema.Set{}
+	for _, k := range []string{"origins", "headers", "exposed_headers"} {
+		before[k] = d.Get("config.0." + k).(*schema.Set)
+	}
+
+	expectNoError(t, r.ReadContext(context.Background(), d, kong.client()))
+
+	for k, want := range before {
+		if got := d.Get("config.0." + k).(*schema.Set); !got.Equal(want) {
+			t.Errorf("%s read back as %v, want %v", k, got.List(), want.List())
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginExitTransformer : the Enterprise exit-transformer plugin, customizing the responses generated by
+// Kong with Lua functions
+func resourceKongPluginExitTransformer() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "exit-transformer",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"functions": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+				Required:    true,
+				MinItems:    1,
+				Description: "Lua chunks returning a function of status, body and headers, applied in order to the responses generated by Kong.",
+			},
+
+			"handle_unknown": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to also transform the 404 responses of requests matching no route. Only applies to global plugins.",
+			},
+
+			"handle_unexpected": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to also transform the responses of errors raised before the plugin ran, such as 400 and 500 errors.",
+			},
+		},
+	})
+}
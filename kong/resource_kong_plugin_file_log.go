@@ -0,0 +1,41 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginFileLog() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "file-log",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Path of the log file on the Kong nodes, created when missing.",
+			},
+
+			"reopen": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to reopen the file for every request, e.g. to follow log rotations.",
+			},
+
+			"custom_fields_by_lua": customFieldsByLuaSchema(),
+		},
+	})
+}
+
+// customFieldsByLuaSchema returns the custom_fields_by_lua property shared by the log plugins.
+func customFieldsByLuaSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Optional:    true,
+		Description: "Fields added to the log entries, mapped to the Lua code returning their value.",
+	}
+}
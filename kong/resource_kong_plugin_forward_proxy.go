@@ -0,0 +1,87 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginForwardProxy : the Enterprise forward-proxy plugin, reaching the upstream service through an
+// HTTP proxy
+func resourceKongPluginForwardProxy() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "forward-proxy",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"http_proxy_host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"config.0.http_proxy_host", "config.0.https_proxy_host"},
+				RequiredWith: []string{"config.0.http_proxy_port"},
+				Description:  "Host of the proxy used for http upstream services.",
+			},
+
+			"http_proxy_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				RequiredWith: []string{"config.0.http_proxy_host"},
+				Description:  "Port of the proxy used for http upstream services.",
+			},
+
+			"https_proxy_host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"config.0.http_proxy_host", "config.0.https_proxy_host"},
+				RequiredWith: []string{"config.0.https_proxy_port"},
+				Description:  "Host of the proxy used for https upstream services.",
+			},
+
+			"https_proxy_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				RequiredWith: []string{"config.0.https_proxy_host"},
+				Description:  "Port of the proxy used for https upstream services.",
+			},
+
+			"proxy_scheme": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "http",
+				ValidateFunc: validation.StringInSlice([]string{"http"}, false),
+				Description:  "The protocol used to reach the proxy.",
+			},
+
+			"auth_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Username for basic authentication to the proxy.",
+			},
+
+			"auth_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for basic authentication to the proxy.",
+			},
+
+			"https_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to verify the certificate of https upstream services.",
+			},
+
+			"x_headers": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "append",
+				ValidateFunc: validation.StringInSlice([]string{"append", "transparent", "delete"}, false),
+				Description:  "How the X-Forwarded-* and X-Real-IP headers are sent to the proxy: append the Kong node, transparent to leave them untouched or delete.",
+			},
+		},
+	})
+}
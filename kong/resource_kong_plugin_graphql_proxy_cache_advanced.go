@@ -0,0 +1,51 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginGraphQLProxyCacheAdvanced : the Enterprise graphql-proxy-cache-advanced plugin, caching the
+// responses of GraphQL queries
+func resourceKongPluginGraphQLProxyCacheAdvanced() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:          "graphql-proxy-cache-advanced",
+		CustomizeDiff: validateProxyCacheAdvancedConfig,
+
+		Config: map[string]*schema.Schema{
+			"strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "memory",
+				ValidateFunc: validation.StringInSlice([]string{"memory", "redis"}, false),
+				Description:  "Where responses are cached, either memory or redis.",
+			},
+
+			"cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "How long in seconds responses are cached.",
+			},
+
+			"vary_headers": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Description: "Headers part of the cache key, none when empty.",
+			},
+
+			"bypass_on_err": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to proxy requests to the upstream service when the cache storage fails, instead of returning an error.",
+			},
+
+			"memory": proxyCacheMemorySchema(),
+
+			"redis": redisConfigSchema(),
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKongPluginGRPCWeb() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "grpc-web",
+
+		Config: map[string]*schema.Schema{
+			"proto": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path on the Kong nodes of the .proto file describing the service, required to use JSON payloads.",
+			},
+
+			"pass_stripped_path": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to pass the path stripped by the route to the upstream gRPC service.",
+			},
+
+			"allow_origin_header": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "*",
+				Description: "Value of the Access-Control-Allow-Origin header.",
+			},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hmacAlgorithms are the digest algorithms supported by the hmac-auth plugin.
+var hmacAlgorithms = []string{"hmac-sha1", "hmac-sha256", "hmac-sha384", "hmac-sha512"}
+
+func resourceKongPluginHMACAuth() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "hmac-auth",
+
+		Config: map[string]*schema.Schema{
+			"hide_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to remove the Authorization header before proxying the request to the upstream service.",
+			},
+
+			"clock_skew": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Clock skew in seconds tolerated on the Date header to prevent replay attacks.",
+			},
+
+			"validate_request_body": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to verify the request body against the Digest header.",
+			},
+
+			"enforce_headers": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Headers the client must at least include in the signature.",
+			},
+
+			"algorithms": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(hmacAlgorithms, false)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Digest algorithms accepted from clients, among hmac-sha1, hmac-sha256, hmac-sha384 and hmac-sha512.",
+			},
+
+			"anonymous": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID or username of the consumer used when authentication fails. Failed requests are rejected when empty.",
+			},
+
+			"realm": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm sent in the WWW-Authenticate header of rejected requests.",
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginIPRestriction() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "ip-restriction",
+		ConfigRequired: true,
+		ToKong:         clearUnsetPluginConfig("allow", "deny"),
+
+		Config: map[string]*schema.Schema{
+			"allow": {
+				Type:         schema.TypeSet,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR)},
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"config.0.allow", "config.0.deny"},
+				Description:  "IP addresses or CIDR ranges allowed to use the service or route.",
+			},
+
+			"deny": {
+				Type:         schema.TypeSet,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR)},
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"config.0.allow", "config.0.deny"},
+				Description:  "IP addresses or CIDR ranges denied from using the service or route.",
+			},
+
+			"status": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(100, 599),
+				Description:  "The status code returned to denied clients, 403 by default.",
+			},
+
+			"message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The message returned to denied clients.",
+			},
+		},
+	})
+}
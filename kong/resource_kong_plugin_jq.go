@@ -0,0 +1,104 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginJQ : the Enterprise jq plugin, transforming JSON bodies of requests and responses with jq
+// programs, typically written as heredocs
+func resourceKongPluginJQ() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "jq",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"request_jq_program": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"config.0.request_jq_program", "config.0.response_jq_program"},
+				Description:  "The jq program applied to the request body.",
+			},
+
+			"request_jq_program_options": jqProgramOptionsSchema(),
+
+			"request_if_media_type": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Media types of the request bodies transformed.",
+			},
+
+			"response_jq_program": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"config.0.request_jq_program", "config.0.response_jq_program"},
+				Description:  "The jq program applied to the response body.",
+			},
+
+			"response_jq_program_options": jqProgramOptionsSchema(),
+
+			"response_if_media_type": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Media types of the response bodies transformed.",
+			},
+
+			"response_if_status_code": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(100, 599)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Status codes of the responses transformed.",
+			},
+		},
+	})
+}
+
+// jqProgramOptionsSchema returns the output options of a jq program.
+func jqProgramOptionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Description: "Output options of the jq program.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"compact_output": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to output compact JSON, like jq -c.",
+				},
+				"raw_output": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether to output strings without quotes, like jq -r.",
+				},
+				"join_output": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether to output results without newlines, like jq -j.",
+				},
+				"ascii_output": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether to escape non ASCII characters, like jq -a.",
+				},
+				"sort_keys": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether to sort the keys of objects, like jq -S.",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginJWT() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:          "jwt",
+		CustomizeDiff: validateJWTConfig,
+
+		Config: map[string]*schema.Schema{
+			"uri_param_names": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Query string parameters inspected to retrieve the token.",
+			},
+
+			"cookie_names": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Cookies inspected to retrieve the token.",
+			},
+
+			"header_names": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "HTTP headers inspected to retrieve the token.",
+			},
+
+			"claims_to_verify": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"exp", "nbf"}, false)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Registered claims verified by the plugin, among exp and nbf.",
+			},
+
+			"key_claim_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "iss",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The claim holding the key used to find the matching JWT credential.",
+			},
+
+			"secret_is_base64": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the credential secrets are base64 encoded.",
+			},
+
+			"maximum_expiration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 31536000),
+				Description:  "Maximum lifetime of tokens in seconds, 0 for no limit. Requires exp in claims_to_verify.",
+			},
+
+			"run_on_preflight": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to authenticate OPTIONS preflight requests.",
+			},
+
+			"anonymous": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID or username of the consumer used when authentication fails. Failed requests are rejected when empty.",
+			},
+		},
+	})
+}
+
+// validateJWTConfig checks that a maximum expiration is only set along with the verification of the exp claim.
+func validateJWTConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("config.0.claims_to_verify") || d.Get("config.0.maximum_expiration").(int) == 0 {
+		return nil
+	}
+
+	claims, ok := d.Get("config.0.claims_to_verify").(*schema.Set)
+	if !ok || !claims.Contains("exp") {
+		return fmt.Errorf("config.claims_to_verify must contain exp when config.maximum_expiration is set")
+	}
+
+	return nil
+}
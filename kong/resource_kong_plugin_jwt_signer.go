@@ -0,0 +1,226 @@
+package kong
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginJWTSigner : the Enterprise jwt-signer plugin, verifying the access and channel tokens of the
+// requests and signing them again for the upstream service
+//
+// Both tokens share the same settings, prefixed by access_token and channel_token. Every property is optional and
+// computed, as they vary between Kong versions.
+func resourceKongPluginJWTSigner() *schema.Resource {
+	config := map[string]*schema.Schema{
+		"realm": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The realm sent in the WWW-Authenticate header of rejected requests.",
+		},
+
+		"enable_hs_signatures": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether tokens signed with the HS algorithms are accepted.",
+		},
+
+		"enable_instrumentation": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to log the timings of the plugin, for troubleshooting.",
+		},
+	}
+
+	for prefix, token := range map[string]string{"access_token": "access token", "channel_token": "channel token"} {
+		for k, s := range jwtSignerTokenSchema(token) {
+			config[fmt.Sprintf(k, prefix)] = s
+		}
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:   "jwt-signer",
+		Config: config,
+	})
+}
+
+// jwtSignerTokenSchema returns the settings of a token, keyed by the format of their name.
+func jwtSignerTokenSchema(token string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"%s_request_header": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("The header of the request holding the %s.", token),
+		},
+		"%s_optional": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether requests without the %s are proxied.", token),
+		},
+		"%s_leeway": {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.FloatAtLeast(0),
+			Description:  fmt.Sprintf("Clock skew in seconds tolerated when verifying the expiry of the %s.", token),
+		},
+		"%s_signing_algorithm": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: validateOneOf(jwtAlgorithms...),
+			Description:      fmt.Sprintf("The algorithm used to sign the %s sent to the upstream service.", token),
+		},
+		"%s_keyset": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Name of the keyset of Kong used to sign the %s sent to the upstream service.", token),
+		},
+		"%s_jwks_uri": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			Description:  fmt.Sprintf("The JWKS URI of the keys verifying the signature of the %s.", token),
+		},
+		"%s_jwks_uri_client_username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Username for basic authentication to the JWKS URI.",
+		},
+		"%s_jwks_uri_client_password": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Password for basic authentication to the JWKS URI.",
+		},
+		"%s_jwks_uri_rotate_period": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "How often in seconds the keys of the JWKS URI are reloaded, 0 to only reload on unknown keys.",
+		},
+		"%s_upstream_header": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("The header of the request to the upstream service holding the signed %s, e.g. Authorization:Bearer.", token),
+		},
+		"%s_upstream_leeway": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Seconds added to the expiry of the %s sent to the upstream service.", token),
+		},
+		"original_%s_upstream_header": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("The header of the request to the upstream service holding the original %s.", token),
+		},
+		"%s_scopes_required": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Scopes the %s must have. Items may combine scopes separated by spaces, all of which are required.", token),
+		},
+		"%s_scopes_claim": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Path of the claim of the %s holding the scopes.", token),
+		},
+		"%s_consumer_claim": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Path of the claim of the %s identifying the consumer.", token),
+		},
+		"%s_consumer_by": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"id", "username", "custom_id"}, false)},
+			Optional:    true,
+			Computed:    true,
+			Description: "Consumer fields matched against the consumer claim, among id, username and custom_id.",
+		},
+		"%s_introspection_endpoint": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			Description:  fmt.Sprintf("The OAuth 2.0 introspection endpoint verifying opaque %ss.", token),
+		},
+		"%s_introspection_authorization": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Value of the Authorization header sent to the introspection endpoint.",
+		},
+		"%s_introspection_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Timeout in milliseconds of the requests to the introspection endpoint.",
+		},
+		"verify_%s_signature": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether to verify the signature of the %s.", token),
+		},
+		"verify_%s_expiry": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether to verify the expiry of the %s.", token),
+		},
+		"verify_%s_scopes": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether to verify the scopes of the %s.", token),
+		},
+		"enable_%s_introspection": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether to introspect opaque %ss.", token),
+		},
+		"add_%s_claims": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Claims added to the %s sent to the upstream service, when missing.", token),
+		},
+		"set_%s_claims": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Claims set on the %s sent to the upstream service, replacing existing ones.", token),
+		},
+		"remove_%s_claims": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("Claims removed from the %s sent to the upstream service.", token),
+		},
+	}
+}
@@ -0,0 +1,216 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginKafkaLog : the Enterprise kafka-log plugin, publishing the request logs to a Kafka topic
+func resourceKongPluginKafkaLog() *schema.Resource {
+	config := kafkaConfigSchema()
+
+	config["custom_fields_by_lua"] = customFieldsByLuaSchema()
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "kafka-log",
+		ConfigRequired: true,
+		Config:         config,
+	})
+}
+
+// kafkaConfigSchema returns the config properties shared by kafka-log and kafka-upstream.
+func kafkaConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"bootstrap_servers": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Description: "The Kafka brokers used to discover the cluster.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"host": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "Host of the broker.",
+					},
+					"port": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IsPortNumber,
+						Description:  "Port of the broker.",
+					},
+				},
+			},
+		},
+
+		"topic": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The topic messages are published to.",
+		},
+
+		"cluster_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Identifies the cluster when several plugins use different clusters, generated by Kong when not set.",
+		},
+
+		"timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Socket timeout in milliseconds.",
+		},
+
+		"keepalive": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      60000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "How long in milliseconds an idle connection to a broker is kept open.",
+		},
+
+		"keepalive_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether connections to the brokers are kept open.",
+		},
+
+		"authentication": {
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Description: "SASL authentication to the brokers.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"strategy": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"sasl"}, false),
+						Description:  "The authentication strategy, sasl to authenticate to the brokers.",
+					},
+					"mechanism": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"}, false),
+						Description:  "The SASL mechanism.",
+					},
+					"tokenauth": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "Whether the credentials are delegation tokens, for the SCRAM mechanisms.",
+					},
+					"user": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Username, or delegation token ID.",
+					},
+					"password": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Password, or delegation token HMAC.",
+					},
+				},
+			},
+		},
+
+		"security": {
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Description: "TLS connection to the brokers.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ssl": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "Whether to connect to the brokers over TLS.",
+					},
+					"certificate_id": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsUUID,
+						Description:  "ID of the client certificate used for mutual TLS, e.g. kong_certificate.kafka.id.",
+					},
+				},
+			},
+		},
+
+		"producer_request_acks": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntInSlice([]int{-1, 0, 1}),
+			Description:  "Acknowledgments required from the brokers: -1 for all in-sync replicas, 0 for none and 1 for the leader only.",
+		},
+
+		"producer_request_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      2000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Timeout in milliseconds of the produce requests.",
+		},
+
+		"producer_request_limits_messages_per_request": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      200,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Maximum number of messages in a produce request.",
+		},
+
+		"producer_request_limits_bytes_per_request": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1048576,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Maximum size in bytes of a produce request.",
+		},
+
+		"producer_request_retries_max_attempts": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Maximum number of retries of a failed produce request.",
+		},
+
+		"producer_request_retries_backoff_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      100,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Backoff in milliseconds between retries.",
+		},
+
+		"producer_async": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether messages are buffered and sent in batches in the background.",
+		},
+
+		"producer_async_flush_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Maximum time in milliseconds messages are buffered, in asynchronous mode.",
+		},
+
+		"producer_async_buffering_limits_messages_in_memory": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      50000,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Maximum number of messages buffered, in asynchronous mode.",
+		},
+	}
+}
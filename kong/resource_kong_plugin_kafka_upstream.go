@@ -0,0 +1,42 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceKongPluginKafkaUpstream : the Enterprise kafka-upstream plugin, publishing the requests to a Kafka topic
+// instead of proxying them to the upstream service
+func resourceKongPluginKafkaUpstream() *schema.Resource {
+	config := kafkaConfigSchema()
+
+	config["forward_method"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to include the method of the request in the message.",
+	}
+	config["forward_uri"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to include the path and query string of the request in the message.",
+	}
+	config["forward_headers"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to include the headers of the request in the message.",
+	}
+	config["forward_body"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Whether to include the body of the request in the message.",
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "kafka-upstream",
+		ConfigRequired: true,
+		Config:         config,
+	})
+}
@@ -0,0 +1,71 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginKeyAuth() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "key-auth",
+
+		Config: map[string]*schema.Schema{
+			"key_names": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Names of the headers, query string parameters or body fields in which the plugin looks for a key.",
+			},
+
+			"hide_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to remove the key from the request before proxying it to the upstream service.",
+			},
+
+			"key_in_header": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the plugin reads the key from the request headers.",
+			},
+
+			"key_in_query": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the plugin reads the key from the query string parameters.",
+			},
+
+			"key_in_body": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the plugin reads the key from the request body.",
+			},
+
+			"run_on_preflight": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to authenticate OPTIONS preflight requests.",
+			},
+
+			"anonymous": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID or username of the consumer used when authentication fails, e.g. kong_consumer.anonymous.id. Failed requests are rejected when empty.",
+			},
+
+			"realm": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm sent in the WWW-Authenticate header of rejected requests.",
+			},
+		},
+	})
+}
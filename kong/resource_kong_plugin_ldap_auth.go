@@ -0,0 +1,192 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginLDAPAuth() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "ldap-auth",
+		ConfigRequired: true,
+		Config:         ldapAuthConfigSchema(),
+	})
+}
+
+// resourceKongPluginLDAPAuthAdvanced : the Enterprise variant, binding with a service account and mapping LDAP groups
+func resourceKongPluginLDAPAuthAdvanced() *schema.Resource {
+	config := ldapAuthConfigSchema()
+
+	config["bind_dn"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The DN of the account used to search for users, instead of binding as the user itself.",
+	}
+	config["ldap_password"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "The password of the bind_dn account.",
+	}
+	config["group_base_dn"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Base DN of the group searches, defaults to base_dn.",
+	}
+	config["group_name_attribute"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Attribute holding the name of a group, defaults to attribute.",
+	}
+	config["group_member_attribute"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "memberOf",
+		Description: "Attribute of the user entry listing the groups it is a member of.",
+	}
+	config["groups_required"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+		Optional:    true,
+		Computed:    true,
+		Description: "Groups the user must be a member of. Items may combine groups with AND or OR.",
+	}
+	config["consumer_optional"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether authenticated users don't need a matching consumer.",
+	}
+	config["consumer_by"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"username", "custom_id"}, false)},
+		Optional:    true,
+		Computed:    true,
+		Description: "Consumer fields matched against the LDAP username, among username and custom_id.",
+	}
+	config["log_search_results"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Whether to log the results of the LDAP searches, for troubleshooting.",
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "ldap-auth-advanced",
+		ConfigRequired: true,
+		Config:         config,
+	})
+}
+
+// ldapAuthConfigSchema returns the config properties shared by ldap-auth and ldap-auth-advanced.
+func ldapAuthConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ldap_host": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Host of the LDAP server.",
+		},
+
+		"ldap_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      389,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port of the LDAP server.",
+		},
+
+		"ldaps": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"config.0.start_tls"},
+			Description:   "Whether to connect over LDAPS.",
+		},
+
+		"start_tls": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"config.0.ldaps"},
+			Description:   "Whether to upgrade the connection with StartTLS.",
+		},
+
+		"verify_ldap_host": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to verify the certificate of the LDAP server.",
+		},
+
+		"base_dn": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Base DN under which users are searched, e.g. dc=example,dc=com.",
+		},
+
+		"attribute": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Attribute matched against the username, e.g. cn or uid.",
+		},
+
+		"cache_ttl": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      60,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "How long in seconds successful authentications are cached.",
+		},
+
+		"hide_credentials": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to remove the credentials from the request before proxying it to the upstream service.",
+		},
+
+		"timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Timeout in milliseconds of the requests to the LDAP server.",
+		},
+
+		"keepalive": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      60000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "How long in milliseconds an idle connection to the LDAP server is kept open.",
+		},
+
+		"header_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "ldap",
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The authorization scheme clients use in the Authorization header, e.g. ldap or basic.",
+		},
+
+		"anonymous": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "ID or username of the consumer used when authentication fails. Failed requests are rejected when empty.",
+		},
+
+		"realm": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The realm sent in the WWW-Authenticate header of rejected requests.",
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginMTLSAuth : the Enterprise mtls-auth plugin, authenticating clients with their TLS certificate
+func resourceKongPluginMTLSAuth() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "mtls-auth",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"ca_certificates": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsUUID},
+				Required:    true,
+				MinItems:    1,
+				Description: "IDs of the CA certificates trusted to sign client certificates, e.g. kong_ca_certificate.ca.id.",
+			},
+
+			"skip_consumer_lookup": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to skip matching the certificate with a consumer, only verifying it.",
+			},
+
+			"consumer_by": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"username", "custom_id"}, false)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Consumer fields matched against the certificate subject, among username and custom_id.",
+			},
+
+			"authenticated_group_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "CN",
+				ValidateFunc: validation.StringInSlice([]string{"CN", "DN"}, false),
+				Description:  "The certificate property used as the authenticated group when skipping the consumer lookup.",
+			},
+
+			"revocation_check_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "IGNORE_CA_ERROR",
+				ValidateFunc: validation.StringInSlice([]string{"SKIP", "IGNORE_CA_ERROR", "STRICT"}, false),
+				Description:  "How certificate revocation is checked through OCSP or CRL.",
+			},
+
+			"http_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in milliseconds of the OCSP and CRL requests.",
+			},
+
+			"cert_cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How long in milliseconds the revocation check results are cached.",
+			},
+
+			"cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How long in seconds the consumer lookups are cached.",
+			},
+
+			"send_ca_dn": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to send the DN of the trusted CAs in the TLS handshake.",
+			},
+
+			"allow_partial_chain": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether an intermediate CA certificate can be trusted without its root.",
+			},
+
+			"anonymous": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID or username of the consumer used when authentication fails. Failed requests are rejected when empty.",
+			},
+		},
+	})
+}
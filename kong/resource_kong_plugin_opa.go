@@ -0,0 +1,74 @@
+package kong
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginOPA : the Enterprise opa plugin, authorizing requests with an Open Policy Agent server
+func resourceKongPluginOPA() *schema.Resource {
+	config := map[string]*schema.Schema{
+		"opa_protocol": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "http",
+			ValidateFunc: validation.StringInSlice([]string{"http", "https"}, false),
+			Description:  "Protocol used to reach the OPA server.",
+		},
+
+		"opa_host": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "localhost",
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Host of the OPA server.",
+		},
+
+		"opa_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      8181,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port of the OPA server.",
+		},
+
+		"opa_path": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/v1/data/`), "must be a path of the OPA data API such as /v1/data/example/allow"),
+			Description:  "Path of the policy decision in the OPA data API.",
+		},
+
+		"ssl_verify": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to verify the certificate of the OPA server over https.",
+		},
+	}
+
+	// Input toggles, all disabled by default.
+	for k, description := range map[string]string{
+		"include_service_in_opa_input":          "Whether to include the service in the input of the policy.",
+		"include_route_in_opa_input":            "Whether to include the route in the input of the policy.",
+		"include_consumer_in_opa_input":         "Whether to include the consumer in the input of the policy.",
+		"include_body_in_opa_input":             "Whether to include the raw body of the request in the input of the policy.",
+		"include_parsed_json_body_in_opa_input": "Whether to include the body of JSON requests as an object in the input of the policy.",
+		"include_uri_captures_in_opa_input":     "Whether to include the captures of the route paths in the input of the policy.",
+	} {
+		config[k] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: description,
+		}
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "opa",
+		ConfigRequired: true,
+		Config:         config,
+	})
+}
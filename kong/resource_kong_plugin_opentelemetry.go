@@ -0,0 +1,197 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// propagationFormats are the tracing header formats known to Kong.
+var propagationFormats = []string{"w3c", "b3", "b3-single", "jaeger", "ot", "aws", "gcp", "datadog", "instana"}
+
+func resourceKongPluginOpenTelemetry() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "opentelemetry",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"endpoint": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The OTLP/HTTP endpoint receiving the traces, e.g. http://otel-collector:4318/v1/traces.",
+			},
+
+			"headers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Headers sent to the endpoint, typically holding credentials.",
+			},
+
+			"resource_attributes": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Attributes of the resource emitting the spans, e.g. service.name.",
+			},
+
+			"connect_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in milliseconds for establishing a connection to the endpoint.",
+			},
+
+			"send_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in milliseconds for sending data to the endpoint.",
+			},
+
+			"read_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in milliseconds for receiving data from the endpoint.",
+			},
+
+			"header_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(append([]string{"preserve", "ignore"}, propagationFormats...), false),
+				Description:  "The tracing header format injected in the requests to the upstream service. Superseded by propagation on Kong 3.6 and later.",
+			},
+
+			"http_response_header_for_traceid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Header of the response holding the trace ID, none when empty.",
+			},
+
+			"sampling_rate": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.FloatBetween(0, 1),
+				Description:  "The ratio of the requests traced, overriding the tracing_sampling_rate of the Kong nodes.",
+			},
+
+			"batch_span_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of spans sent in a batch, for Kong versions before 3.3. Use queue otherwise.",
+			},
+
+			"batch_flush_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The delay in seconds before a partial batch is sent, for Kong versions before 3.3. Use queue otherwise.",
+			},
+
+			"queue": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Computed:    true,
+				Description: "Batching of the spans sent to the endpoint, for Kong 3.3 and later.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_batch_size": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The number of spans sent in a batch.",
+						},
+						"max_coalescing_delay": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "The delay in seconds before a partial batch is sent.",
+						},
+						"max_entries": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The number of spans the queue holds before dropping new ones.",
+						},
+						"max_retry_time": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "How long in seconds a failing batch is retried before being dropped.",
+						},
+						"initial_retry_delay": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "The delay in seconds before the first retry.",
+						},
+						"max_retry_delay": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "The maximum delay in seconds between retries.",
+						},
+					},
+				},
+			},
+
+			"propagation": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Computed:    true,
+				Description: "How tracing headers are read from and written to requests, for Kong 3.6 and later.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"extract": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(propagationFormats, false)},
+							Optional:    true,
+							Computed:    true,
+							Description: "Header formats read from incoming requests, by order of preference.",
+						},
+						"inject": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(append([]string{"preserve"}, propagationFormats...), false)},
+							Optional:    true,
+							Computed:    true,
+							Description: "Header formats written to the requests to the upstream service, preserve keeping the incoming one.",
+						},
+						"clear": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+							Optional:    true,
+							Computed:    true,
+							Description: "Headers removed from the requests to the upstream service.",
+						},
+						"default_format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice(propagationFormats, false),
+							Description:  "The header format injected when none was extracted.",
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKongPluginPrometheus() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "prometheus",
+
+		Config: map[string]*schema.Schema{
+			"per_consumer": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to label the metrics with the consumer.",
+			},
+
+			// The following metrics are opt-in since Kong 3.0 and always exported by earlier versions.
+
+			"status_code_metrics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to export the status code metrics.",
+			},
+
+			"latency_metrics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to export the latency metrics.",
+			},
+
+			"bandwidth_metrics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to export the bandwidth metrics.",
+			},
+
+			"upstream_health_metrics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to export the health metrics of the upstream targets.",
+			},
+		},
+	})
+}
@@ -0,0 +1,206 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginProxyCacheAdvanced : the Enterprise proxy-cache-advanced plugin, caching responses in memory or
+// in redis
+func resourceKongPluginProxyCacheAdvanced() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "proxy-cache-advanced",
+		ConfigRequired: true,
+		ToKong:         expandProxyCacheAdvancedConfig,
+		FromKong:       flattenProxyCacheAdvancedConfig,
+		CustomizeDiff:  validateProxyCacheAdvancedConfig,
+
+		Config: map[string]*schema.Schema{
+			"strategy": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"memory", "redis"}, false),
+				Description:  "Where responses are cached, either memory or redis.",
+			},
+
+			"response_code": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(100, 900)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Status codes of the cacheable responses.",
+			},
+
+			"request_method": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"GET", "HEAD", "POST", "PATCH", "PUT"}, false)},
+				Optional:    true,
+				Computed:    true,
+				Description: "Methods of the cacheable requests.",
+			},
+
+			"content_type": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Computed:    true,
+				Description: "Content types of the cacheable responses, * matching any.",
+			},
+
+			"cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "How long in seconds responses are cached.",
+			},
+
+			"storage_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How long in seconds responses are kept in the storage, allowing stale responses to be served.",
+			},
+
+			"cache_control": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to honor the Cache-Control headers of requests and responses.",
+			},
+
+			"ignore_uri_case": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to ignore the case of the path in the cache key.",
+			},
+
+			"bypass_on_err": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to proxy requests to the upstream service when the cache storage fails, instead of returning an error.",
+			},
+
+			"vary_query_params": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Description: "Query string parameters part of the cache key, all when empty.",
+			},
+
+			"vary_headers": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+				Optional:    true,
+				Description: "Headers part of the cache key, none when empty.",
+			},
+
+			"response_headers": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Computed:    true,
+				Description: "The cache headers added to the responses.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"age": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether to add the Age header.",
+						},
+						"x_cache_status": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether to add the X-Cache-Status header.",
+						},
+						"x_cache_key": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether to add the X-Cache-Key header.",
+						},
+					},
+				},
+			},
+
+			"memory": proxyCacheMemorySchema(),
+
+			"redis": redisConfigSchema(),
+		},
+	})
+}
+
+// proxyCacheMemorySchema returns the memory block shared by the proxy cache plugins.
+func proxyCacheMemorySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Description: "Settings of the memory strategy.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"dictionary_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "kong_db_cache",
+					Description: "The shared dictionary where responses are cached.",
+				},
+			},
+		},
+	}
+}
+
+// proxyCacheResponseHeaders maps the attributes of the response_headers block to the headers Kong expects.
+var proxyCacheResponseHeaders = map[string]string{
+	"age":            "age",
+	"x_cache_status": "X-Cache-Status",
+	"x_cache_key":    "X-Cache-Key",
+}
+
+func expandProxyCacheAdvancedConfig(config map[string]interface{}) error {
+	if headers, ok := config["response_headers"].(map[string]interface{}); ok {
+		for k, header := range proxyCacheResponseHeaders {
+			if v, ok := headers[k]; ok {
+				delete(headers, k)
+				headers[header] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+func flattenProxyCacheAdvancedConfig(config map[string]interface{}) {
+	if headers, ok := config["response_headers"].(map[string]interface{}); ok {
+		for k, header := range proxyCacheResponseHeaders {
+			if v, ok := headers[header]; ok {
+				delete(headers, header)
+				headers[k] = v
+			}
+		}
+	}
+}
+
+// validateProxyCacheAdvancedConfig checks that the redis strategy comes with its connection settings, for the proxy
+// cache plugins.
+func validateProxyCacheAdvancedConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("config.0.strategy").(string) != "redis" || !d.NewValueKnown("config.0.redis") {
+		return nil
+	}
+
+	if d.Get("config.0.redis.0.host").(string) == "" && len(d.Get("config.0.redis.0.sentinel_addresses").([]interface{})) == 0 &&
+		len(d.Get("config.0.redis.0.cluster_addresses").([]interface{})) == 0 {
+		return fmt.Errorf("config.redis must set host, sentinel_addresses or cluster_addresses when config.strategy is redis")
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginRateLimitingAdvanced() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "rate-limiting-advanced",
+		ConfigRequired: true,
+		CustomizeDiff:  validateRateLimitingAdvancedConfig,
+
+		Config: map[string]*schema.Schema{
+			"limit": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntAtLeast(1)},
+				Required:    true,
+				Description: "One or more requests-per-window limits to apply, paired by position with window_size.",
+			},
+
+			"window_size": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntAtLeast(1)},
+				Required:    true,
+				Description: "One or more window sizes in seconds, paired by position with limit.",
+			},
+
+			"window_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "sliding",
+				ValidateFunc: validation.StringInSlice([]string{"sliding", "fixed"}, false),
+				Description:  "Sets the time window type to either sliding or fixed.",
+			},
+
+			"identifier": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "consumer",
+				ValidateFunc: validation.StringInSlice([]string{"ip", "credential", "consumer", "service", "header", "path", "consumer-group"}, false),
+				Description:  "The type of identifier used to generate the rate limit key.",
+			},
+
+			"header_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Header name to use as the rate limit key when identifier is header.",
+			},
+
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Path to use as the rate limit key when identifier is path.",
+			},
+
+			"dictionary_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "kong_rate_limiting_counters",
+				Description: "The shared dictionary where counters are stored.",
+			},
+
+			"sync_rate": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Computed:    true,
+				Description: "How often to sync counter data to the central data store, in seconds. 0 syncs synchronously and -1 ignores the central store.",
+			},
+
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The rate limiting library namespace, generated by Kong when not set. Plugins sharing a namespace share their counters.",
+			},
+
+			"strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cluster", "redis", "local"}, false),
+				Description:  "The rate limiting strategy to use for retrieving and incrementing the limits.",
+			},
+
+			"hide_client_headers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to hide the informative rate limiting headers from the response.",
+			},
+
+			"retry_after_jitter_max": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "The upper bound of a jitter, in seconds, added to the Retry-After header of denied requests.",
+			},
+
+			"enforce_consumer_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to apply the limits configured on consumer groups.",
+			},
+
+			"consumer_groups": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Computed:    true,
+				Description: "The consumer groups whose limits are enforced when enforce_consumer_groups is true.",
+			},
+
+			"disable_penalty": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether denied requests are left out of the counters when using a sliding window.",
+			},
+
+			"error_code": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The status code returned when the limit is exceeded.",
+			},
+
+			"error_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The message returned when the limit is exceeded.",
+			},
+
+			"redis": redisConfigSchema(),
+		},
+	})
+}
+
+// redisConfigSchema returns the redis block shared by the plugins supporting a redis strategy.
+func redisConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Description: "Connection settings of the redis strategy, for a single node, a sentinel or a cluster deployment.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"host": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "Host of the redis server.",
+				},
+				"port": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IsPortNumber,
+					Description:  "Port of the redis server.",
+				},
+				"database": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Database to use for the redis connection.",
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "Username for redis ACL authentication.",
+				},
+				"password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Password for redis authentication.",
+				},
+				"timeout": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Connection timeout in milliseconds.",
+				},
+				"connect_timeout": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Timeout in milliseconds for establishing a connection.",
+				},
+				"send_timeout": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Timeout in milliseconds for sending data.",
+				},
+				"read_timeout": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Timeout in milliseconds for receiving data.",
+				},
+				"keepalive_pool_size": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Size of the connection pool per worker.",
+				},
+				"keepalive_backlog": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					Description: "Number of connection requests queued when the pool is exhausted.",
+				},
+				"ssl": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to connect to redis over TLS.",
+				},
+				"ssl_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to verify the certificate of the redis server.",
+				},
+				"server_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "The server name used for SNI when connecting over TLS.",
+				},
+				"sentinel_master": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "Name of the sentinel master, for sentinel deployments.",
+				},
+				"sentinel_role": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringInSlice([]string{"master", "slave", "any"}, false),
+					Description:  "Role of the sentinel node to connect to.",
+				},
+				"sentinel_addresses": {
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Optional:    true,
+					Computed:    true,
+					Description: "Sentinel addresses as host:port, for sentinel deployments.",
+				},
+				"sentinel_username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "Username for sentinel ACL authentication.",
+				},
+				"sentinel_password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Password for sentinel authentication.",
+				},
+				"cluster_addresses": {
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Optional:    true,
+					Computed:    true,
+					Description: "Cluster node addresses as host:port, for cluster deployments.",
+				},
+			},
+		},
+	}
+}
+
+// validateRateLimitingAdvancedConfig checks that every limit has a window and that identifiers have their key set.
+func validateRateLimitingAdvancedConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown("config.0.limit") && d.NewValueKnown("config.0.window_size") {
+		limits := d.Get("config.0.limit").([]interface{})
+		windows := d.Get("config.0.window_size").([]interface{})
+		if len(limits) != len(windows) {
+			return fmt.Errorf("config.limit and config.window_size must have the same number of items, got %d limits and %d window sizes", len(limits), len(windows))
+		}
+
+		seen := make(map[int]bool)
+		for _, w := range windows {
+			if seen[w.(int)] {
+				return fmt.Errorf("config.window_size must not contain the same window size twice, found %d more than once", w.(int))
+			}
+			seen[w.(int)] = true
+		}
+	}
+
+	switch d.Get("config.0.identifier").(string) {
+	case "header":
+		if d.NewValueKnown("config.0.header_name") && d.Get("config.0.header_name").(string) == "" {
+			return fmt.Errorf("config.header_name is required when config.identifier is header")
+		}
+	case "path":
+		if d.NewValueKnown("config.0.path") && d.Get("config.0.path").(string) == "" {
+			return fmt.Errorf("config.path is required when config.identifier is path")
+		}
+	}
+
+	if d.Get("config.0.enforce_consumer_groups").(bool) && d.NewValueKnown("config.0.consumer_groups") &&
+		len(d.Get("config.0.consumer_groups").([]interface{})) == 0 {
+		return fmt.Errorf("config.consumer_groups is required when config.enforce_consumer_groups is true")
+	}
+
+	return nil
+}
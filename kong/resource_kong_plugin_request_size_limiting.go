@@ -0,0 +1,37 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginRequestSizeLimiting() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "request-size-limiting",
+
+		Config: map[string]*schema.Schema{
+			"allowed_payload_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      128,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum size of the request body, in size_unit.",
+			},
+
+			"size_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "megabytes",
+				ValidateFunc: validation.StringInSlice([]string{"megabytes", "kilobytes", "bytes"}, false),
+				Description:  "The unit of allowed_payload_size.",
+			},
+
+			"require_content_length": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to reject requests without a Content-Length header.",
+			},
+		},
+	})
+}
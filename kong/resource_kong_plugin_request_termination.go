@@ -0,0 +1,63 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginRequestTermination() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:   "request-termination",
+		ToKong: clearUnsetPluginConfig("message", "body", "content_type", "trigger"),
+
+		Config: map[string]*schema.Schema{
+			"status_code": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      503,
+				ValidateFunc: validation.IntBetween(100, 599),
+				Description:  "The status code of the response.",
+			},
+
+			"message": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"config.0.body", "config.0.content_type"},
+				Description:   "The message of the default JSON response.",
+			},
+
+			"body": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"config.0.message"},
+				RequiredWith:  []string{"config.0.content_type"},
+				Description:   "The raw body of the response.",
+			},
+
+			"content_type": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"config.0.message"},
+				RequiredWith:  []string{"config.0.body"},
+				Description:   "The Content-Type of the raw body.",
+			},
+
+			"trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of a header or query string parameter which must be present for the request to be terminated. All requests are terminated when empty.",
+			},
+
+			"echo": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to echo the request back in the response, for debugging.",
+			},
+		},
+	})
+}
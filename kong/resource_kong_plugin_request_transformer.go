@@ -0,0 +1,259 @@
+package kong
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// transformerPairRegexp matches the "name:value" strings of the transformer plugins.
+var transformerPairRegexp = regexp.MustCompile(`^[^:]+:`)
+
+// requestTransformerPairs lists the properties of the request-transformer holding "name:value" pairs modeled as maps,
+// by action. Appended values are lists since the same name can be appended more than once.
+var requestTransformerPairs = map[string][]string{
+	"rename":  {"headers", "querystring", "body"},
+	"replace": {"headers", "querystring", "body"},
+	"add":     {"headers", "querystring", "body"},
+}
+
+// requestTransformerAppended lists the properties of the append block of the request-transformer.
+var requestTransformerAppended = []string{"headers", "querystring", "body"}
+
+func resourceKongPluginRequestTransformer() *schema.Resource {
+	config := requestTransformerConfigSchema()
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:     "request-transformer",
+		ToKong:   transformerPairsToKong(requestTransformerPairs),
+		FromKong: transformerPairsFromKong(requestTransformerPairs),
+		Config:   config,
+
+		PreviousConfig: transformerAppendedMapsSchema(config, requestTransformerAppended...),
+		UpgradeConfig:  upgradeTransformerAppended(requestTransformerAppended...),
+	})
+}
+
+// requestTransformerConfigSchema returns the config properties shared by request-transformer and
+// request-transformer-advanced.
+func requestTransformerConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"http_method": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(httpMethodRegexp, "must be an uppercase HTTP method"),
+			Description:  "Changes the method of the request to the upstream service.",
+		},
+
+		"remove": transformerBlockSchema("Removes the given names from the request.", map[string]*schema.Schema{
+			"headers":     transformerNamesSchema("Headers to remove."),
+			"querystring": transformerNamesSchema("Query string parameters to remove."),
+			"body":        transformerNamesSchema("Body parameters to remove."),
+		}),
+
+		"rename": transformerBlockSchema("Renames the keys of the map to their value.", map[string]*schema.Schema{
+			"headers":     transformerPairsSchema("Headers to rename."),
+			"querystring": transformerPairsSchema("Query string parameters to rename."),
+			"body":        transformerPairsSchema("Body parameters to rename."),
+		}),
+
+		"replace": transformerBlockSchema("Replaces the value of the names present in the request.", map[string]*schema.Schema{
+			"headers":     transformerPairsSchema("Headers to replace."),
+			"querystring": transformerPairsSchema("Query string parameters to replace."),
+			"body":        transformerPairsSchema("Body parameters to replace."),
+			"uri": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Replaces the path of the request to the upstream service.",
+			},
+		}),
+
+		"add": transformerBlockSchema("Adds the names missing from the request.", map[string]*schema.Schema{
+			"headers":     transformerPairsSchema("Headers to add."),
+			"querystring": transformerPairsSchema("Query string parameters to add."),
+			"body":        transformerPairsSchema("Body parameters to add."),
+		}),
+
+		"append": transformerBlockSchema("Appends a value to the names of the request, adding them when missing.", map[string]*schema.Schema{
+			"headers":     transformerPairListSchema("Headers to append, e.g. x-forwarded-for:10.0.0.1."),
+			"querystring": transformerPairListSchema("Query string parameters to append, e.g. tag:a."),
+			"body":        transformerPairListSchema("Body parameters to append, e.g. tag:a."),
+		}),
+	}
+}
+
+// transformerBlockSchema returns the block of a transformer action.
+func transformerBlockSchema(description string, fields map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Elem:        &schema.Resource{Schema: fields},
+		Description: description,
+	}
+}
+
+// transformerNamesSchema returns a set of names, e.g. the headers to remove.
+func transformerNamesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+		Optional:    true,
+		Description: description,
+	}
+}
+
+// transformerPairsSchema returns a map of names to values, sent to Kong as a list of "name:value" strings.
+func transformerPairsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		Optional:     true,
+		ValidateFunc: validateTransformerPairs,
+		Description:  description,
+	}
+}
+
+// transformerPairListSchema returns a list of "name:value" strings sent to Kong as they are, for the actions taking the
+// same name more than once.
+func transformerPairListSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringMatch(transformerPairRegexp, "must be a name:value pair")},
+		Optional:    true,
+		Description: description,
+	}
+}
+
+func validateTransformerPairs(v interface{}, k string) ([]string, []error) {
+	var errors []error
+
+	for name := range v.(map[string]interface{}) {
+		if name == "" || strings.Contains(name, ":") {
+			errors = append(errors, fmt.Errorf("%q must only contain names without a colon, got %q", k, name))
+		}
+	}
+
+	return nil, errors
+}
+
+// transformerAppendedMapsSchema returns the config schema of a transformer plugin at schema version 0, where the given
+// properties of the append block were maps like those of the other actions.
+func transformerAppendedMapsSchema(config map[string]*schema.Schema, fields ...string) map[string]*schema.Schema {
+	appended := config["append"]
+
+	block := make(map[string]*schema.Schema)
+	for k, v := range appended.Elem.(*schema.Resource).Schema {
+		block[k] = v
+	}
+	for _, field := range fields {
+		block[field] = transformerPairsSchema(block[field].Description)
+	}
+
+	previous := make(map[string]*schema.Schema, len(config))
+	for k, v := range config {
+		previous[k] = v
+	}
+	previous["append"] = transformerBlockSchema(appended.Description, block)
+
+	return previous
+}
+
+// upgradeTransformerAppended returns an UpgradeConfig function converting the given properties of the append block
+// from maps into lists of "name:value" strings, sorted by name as they were sent to Kong.
+func upgradeTransformerAppended(fields ...string) func(config map[string]interface{}) {
+	return func(config map[string]interface{}) {
+		blocks, _ := config["append"].([]interface{})
+		for _, b := range blocks {
+			block, ok := b.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, field := range fields {
+				m, ok := block[field].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				names := make([]string, 0, len(m))
+				for name := range m {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				values := make([]interface{}, 0, len(names))
+				for _, name := range names {
+					values = append(values, fmt.Sprintf("%s:%v", name, m[name]))
+				}
+				block[field] = values
+			}
+		}
+	}
+}
+
+// transformerPairsToKong returns a ToKong function converting the given maps into lists of "name:value" strings,
+// sorted by name.
+func transformerPairsToKong(pairs map[string][]string) func(config map[string]interface{}) error {
+	return func(config map[string]interface{}) error {
+		for action, fields := range pairs {
+			block, ok := config[action].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, field := range fields {
+				m, ok := block[field].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				names := make([]string, 0, len(m))
+				for name := range m {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				values := make([]interface{}, 0, len(names))
+				for _, name := range names {
+					values = append(values, fmt.Sprintf("%s:%v", name, m[name]))
+				}
+				block[field] = values
+			}
+		}
+
+		return nil
+	}
+}
+
+// transformerPairsFromKong returns a FromKong function converting lists of "name:value" strings back into maps.
+func transformerPairsFromKong(pairs map[string][]string) func(config map[string]interface{}) {
+	return func(config map[string]interface{}) {
+		for action, fields := range pairs {
+			block, ok := config[action].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, field := range fields {
+				items, ok := block[field].([]interface{})
+				if !ok {
+					continue
+				}
+
+				m := make(map[string]interface{}, len(items))
+				for _, item := range items {
+					s, _ := item.(string)
+					if name, value, found := strings.Cut(s, ":"); found {
+						m[name] = value
+					}
+				}
+				block[field] = m
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceKongPluginRequestTransformerAdvanced : the Enterprise variant of request-transformer, filtering the body
+// parameters and supporting templated values such as $(headers.host), which are sent to Kong untouched
+func resourceKongPluginRequestTransformerAdvanced() *schema.Resource {
+	config := requestTransformerConfigSchema()
+
+	config["allow"] = transformerBlockSchema("Only keeps the given names in the request.", map[string]*schema.Schema{
+		"body": transformerNamesSchema("Body parameters to keep, all others are removed."),
+	})
+	config["dots_in_keys"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Whether dots in the names of JSON body parameters are part of the name rather than navigating nested objects.",
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:     "request-transformer-advanced",
+		ToKong:   expandRequestTransformerAdvancedConfig,
+		FromKong: transformerPairsFromKong(requestTransformerPairs),
+		Config:   config,
+
+		PreviousConfig: transformerAppendedMapsSchema(config, requestTransformerAppended...),
+		UpgradeConfig:  upgradeTransformerAppended(requestTransformerAppended...),
+	})
+}
+
+func expandRequestTransformerAdvancedConfig(config map[string]interface{}) error {
+	// An empty list would only keep an empty body, null disables the filter.
+	if allow, ok := config["allow"].(map[string]interface{}); ok {
+		if body, _ := allow["body"].([]interface{}); len(body) == 0 {
+			allow["body"] = nil
+		}
+	}
+
+	return transformerPairsToKong(requestTransformerPairs)(config)
+}
@@ -0,0 +1,81 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceKongPluginRequestTransformerPairs(t *testing.T) {
+	kong := newFakeKong(t)
+	r := resourceKongPluginRequestTransformer()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"config": []interface{}{map[string]interface{}{
+			"add": []interface{}{map[string]interface{}{
+				"headers": map[string]interface{}{"x-b": "2", "x-a": "1"},
+			}},
+			"append": []interface{}{map[string]interface{}{
+				"headers": []interface{}{"x-via:kong", "x-via:terraform"},
+			}},
+		}},
+	})
+
+	expectNoError(t, r.CreateContext(context.Background(), d, kong.client()))
+
+	config, _ := kong.lastRequestTo(http.MethodPost, "plugins").Body["config"].(map[string]interface{})
+	add, _ := config["add"].(map[string]interface{})
+	if want := []interface{}{"x-a:1", "x-b:2"}; !reflect.DeepEqual(add["headers"], want) {
+		t.Errorf("add.headers sent as %v, want %v", add["headers"], want)
+	}
+	appended, _ := config["append"].(map[string]interface{})
+	if want := []interface{}{"x-via:kong", "x-via:terraform"}; !reflect.DeepEqual(appended["headers"], want) {
+		t.Errorf("append.headers sent as %v, want %v", appended["headers"], want)
+	}
+
+	if headers := d.Get("config.0.append.0.headers").([]interface{}); len(headers) != 2 {
+		t.Errorf("append.headers read back as %v, want both values", headers)
+	}
+	if headers := d.Get("config.0.add.0.headers").(map[string]interface{}); headers["x-a"] != "1" || headers["x-b"] != "2" {
+		t.Errorf("add.headers read back as %v", headers)
+	}
+}
+
+func TestResourceKongPluginRequestTransformerUpgradeState(t *testing.T) {
+	r := resourceKongPluginRequestTransformer()
+	if r.SchemaVersion != 1 || len(r.StateUpgraders) != 1 {
+		t.Fatalf("schema version %d with %d upgraders, want version 1 upgraded from 0", r.SchemaVersion, len(r.StateUpgraders))
+	}
+
+	state := map[string]interface{}{
+		"id": "00000000-0000-4000-8000-000000000001",
+		"config": []interface{}{map[string]interface{}{
+			"add": []interface{}{map[string]interface{}{
+				"headers": map[string]interface{}{"x-a": "1"},
+			}},
+			"append": []interface{}{map[string]interface{}{
+				"headers":     map[string]interface{}{"x-b": "2", "x-a": "1"},
+				"querystring": map[string]interface{}{},
+			}},
+		}},
+	}
+
+	upgraded, err := r.StateUpgraders[0].Upgrade(context.Background(), state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := upgraded["config"].([]interface{})[0].(map[string]interface{})
+	appended := config["append"].([]interface{})[0].(map[string]interface{})
+	if want := []interface{}{"x-a:1", "x-b:2"}; !reflect.DeepEqual(appended["headers"], want) {
+		t.Errorf("append.headers upgraded to %v, want %v", appended["headers"], want)
+	}
+	if want := []interface{}{}; !reflect.DeepEqual(appended["querystring"], want) {
+		t.Errorf("append.querystring upgraded to %v, want %v", appended["querystring"], want)
+	}
+	if want := map[string]interface{}{"x-a": "1"}; !reflect.DeepEqual(config["add"].([]interface{})[0].(map[string]interface{})["headers"], want) {
+		t.Errorf("add.headers changed by the upgrade")
+	}
+}
@@ -0,0 +1,147 @@
+package kong
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// rateLimitingPeriods are the periods a limit can be set for.
+var rateLimitingPeriods = []string{"second", "minute", "hour", "day", "month", "year"}
+
+func resourceKongPluginResponseRateLimiting() *schema.Resource {
+	limitSchema := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the limit, incremented by the upstream service through the X-Kong-Limit header, e.g. videos=1.",
+		},
+	}
+	for _, period := range rateLimitingPeriods {
+		limitSchema[period] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The number of units allowed per " + period + ".",
+		}
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "response-ratelimiting",
+		ConfigRequired: true,
+		ToKong:         expandResponseRateLimitingConfig,
+		FromKong:       flattenResponseRateLimitingConfig,
+
+		Config: map[string]*schema.Schema{
+			"limit": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Resource{Schema: limitSchema},
+				Description: "The limits, by name.",
+			},
+
+			"header_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "x-kong-limit",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The response header the upstream service uses to increment the limits.",
+			},
+
+			"limit_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "consumer",
+				ValidateFunc: validation.StringInSlice([]string{"consumer", "credential", "ip"}, false),
+				Description:  "What the limits are counted for, falling back to the IP address when the consumer or the credential can't be identified.",
+			},
+
+			"policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "local",
+				ValidateFunc: validation.StringInSlice([]string{"local", "cluster", "redis"}, false),
+				Description:  "Where the counters are stored.",
+			},
+
+			"fault_tolerant": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to proxy requests when the counters can't be read, instead of returning an error.",
+			},
+
+			"hide_client_headers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to hide the informative rate limiting headers from the response.",
+			},
+
+			"block_on_first_violation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to reject requests as soon as a limit is exceeded, rather than on the next request.",
+			},
+
+			"redis": redisConfigSchema(),
+		},
+	})
+}
+
+// expandResponseRateLimitingConfig sends the limits as a map of periods keyed by name, leaving out the periods not set.
+func expandResponseRateLimitingConfig(config map[string]interface{}) error {
+	items, ok := config["limit"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	limits := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		limit := item.(map[string]interface{})
+
+		periods := make(map[string]interface{})
+		for _, period := range rateLimitingPeriods {
+			if n, ok := limit[period].(int); ok && n > 0 {
+				periods[period] = n
+			}
+		}
+		limits[limit["name"].(string)] = periods
+	}
+
+	delete(config, "limit")
+	config["limits"] = limits
+
+	return nil
+}
+
+func flattenResponseRateLimitingConfig(config map[string]interface{}) {
+	limits, ok := config["limits"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		limit := map[string]interface{}{"name": name}
+		if periods, ok := limits[name].(map[string]interface{}); ok {
+			for period, n := range periods {
+				limit[period] = n
+			}
+		}
+		items = append(items, limit)
+	}
+
+	delete(config, "limits")
+	config["limit"] = items
+}
@@ -0,0 +1,135 @@
+package kong
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// responseTransformerPairs lists the properties of the response-transformer holding "name:value" pairs modeled as
+// maps, by action. Appended headers are a list since the same header can be appended more than once.
+var responseTransformerPairs = map[string][]string{
+	"rename":  {"headers", "json"},
+	"replace": {"headers", "json"},
+	"add":     {"headers", "json"},
+	"append":  {"json"},
+}
+
+func resourceKongPluginResponseTransformer() *schema.Resource {
+	config := map[string]*schema.Schema{
+		"remove": transformerBlockSchema("Removes the given names from the response.", map[string]*schema.Schema{
+			"headers": transformerNamesSchema("Headers to remove."),
+			"json":    transformerNamesSchema("Properties to remove from JSON bodies."),
+		}),
+
+		"rename": transformerBlockSchema("Renames the keys of the map to their value.", map[string]*schema.Schema{
+			"headers": transformerPairsSchema("Headers to rename."),
+			"json":    transformerPairsSchema("Properties of JSON bodies to rename."),
+		}),
+
+		"replace": transformerBlockSchema("Replaces the value of the names present in the response.", map[string]*schema.Schema{
+			"headers":    transformerPairsSchema("Headers to replace."),
+			"json":       transformerPairsSchema("Properties of JSON bodies to replace."),
+			"json_types": transformerJSONTypesSchema(),
+		}),
+
+		"add": transformerBlockSchema("Adds the names missing from the response.", map[string]*schema.Schema{
+			"headers":    transformerPairsSchema("Headers to add."),
+			"json":       transformerPairsSchema("Properties to add to JSON bodies."),
+			"json_types": transformerJSONTypesSchema(),
+		}),
+
+		"append": transformerBlockSchema("Appends a value to the names of the response, adding them when missing.", map[string]*schema.Schema{
+			"headers":    transformerPairListSchema("Headers to append, e.g. x-via:kong."),
+			"json":       transformerPairsSchema("Properties of JSON bodies to append."),
+			"json_types": transformerJSONTypesSchema(),
+		}),
+	}
+
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:     "response-transformer",
+		ToKong:   expandResponseTransformerConfig,
+		FromKong: flattenResponseTransformerConfig,
+		Config:   config,
+
+		PreviousConfig: transformerAppendedMapsSchema(config, "headers"),
+		UpgradeConfig:  upgradeTransformerAppended("headers"),
+	})
+}
+
+// transformerJSONTypesSchema returns the types of the json values of an action, keyed by the names of the json map.
+// Kong expects them as a list in the same order as the json values, with string as the default.
+func transformerJSONTypesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"boolean", "number"}, false)},
+		Optional:     true,
+		ValidateFunc: validateTransformerPairs,
+		Description:  "Types of the json values which are not strings, either boolean or number, keyed by name.",
+	}
+}
+
+func expandResponseTransformerConfig(config map[string]interface{}) error {
+	for _, action := range []string{"replace", "add", "append"} {
+		block, ok := config[action].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		json, _ := block["json"].(map[string]interface{})
+		types, _ := block["json_types"].(map[string]interface{})
+		if len(types) == 0 {
+			block["json_types"] = []interface{}{}
+			continue
+		}
+
+		// Same order as transformerPairsToKong.
+		names := make([]string, 0, len(json))
+		for name := range json {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		list := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			if t, ok := types[name].(string); ok && t != "" {
+				list = append(list, t)
+			} else {
+				list = append(list, "string")
+			}
+		}
+		block["json_types"] = list
+	}
+
+	return transformerPairsToKong(responseTransformerPairs)(config)
+}
+
+func flattenResponseTransformerConfig(config map[string]interface{}) {
+	for _, action := range []string{"replace", "add", "append"} {
+		block, ok := config[action].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		json, _ := block["json"].([]interface{})
+		list, _ := block["json_types"].([]interface{})
+
+		types := make(map[string]interface{})
+		for i, item := range json {
+			if i >= len(list) {
+				break
+			}
+
+			s, _ := item.(string)
+			name, _, _ := strings.Cut(s, ":")
+			if t, _ := list[i].(string); t != "" && t != "string" {
+				types[name] = t
+			}
+		}
+		block["json_types"] = types
+	}
+
+	transformerPairsFromKong(responseTransformerPairs)(config)
+}
@@ -0,0 +1,42 @@
+package kong
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serverlessFunctionPhases are the phases of the request in which the pre-function and post-function plugins run code.
+var serverlessFunctionPhases = []string{"certificate", "rewrite", "access", "header_filter", "body_filter", "log"}
+
+func resourceKongPluginPreFunction() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:   "pre-function",
+		Config: serverlessFunctionsConfigSchema("before"),
+	})
+}
+
+func resourceKongPluginPostFunction() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:   "post-function",
+		Config: serverlessFunctionsConfigSchema("after"),
+	})
+}
+
+// serverlessFunctionsConfigSchema returns a list of Lua chunks for every phase, typically read with file() or
+// written as heredocs.
+func serverlessFunctionsConfigSchema(order string) map[string]*schema.Schema {
+	config := make(map[string]*schema.Schema, len(serverlessFunctionPhases))
+
+	for _, phase := range serverlessFunctionPhases {
+		config[phase] = &schema.Schema{
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+			Optional:    true,
+			Description: fmt.Sprintf("Lua code run in the %s phase, %s the other plugins.", phase, order),
+		}
+	}
+
+	return config
+}
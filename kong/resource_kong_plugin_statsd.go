@@ -0,0 +1,115 @@
+package kong
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// statsdMetrics are the metrics exported by the statsd plugin.
+var statsdMetrics = []string{
+	"request_count", "request_size", "response_size", "latency", "upstream_latency", "kong_latency",
+	"status_count", "unique_users", "request_per_user", "status_count_per_user", "status_count_per_workspace",
+	"status_count_per_user_per_route", "shdict_usage", "cache_datastore_hits_total", "cache_datastore_misses_total",
+}
+
+// statusCodeRangeRegexp matches ranges of status codes such as 200-299.
+var statusCodeRangeRegexp = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
+
+func resourceKongPluginStatsd() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "statsd",
+
+		Config: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "localhost",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Host of the StatsD server.",
+			},
+
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8125,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the StatsD server.",
+			},
+
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "kong",
+				Description: "Prefix of the metric names.",
+			},
+
+			"use_tcp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to send the metrics over TCP instead of UDP.",
+			},
+
+			"allow_status_codes": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringMatch(statusCodeRangeRegexp, "must be a range of status codes such as 200-299")},
+				Optional:    true,
+				Computed:    true,
+				Description: "Ranges of status codes of the requests to report, e.g. 200-299. All requests are reported when empty.",
+			},
+
+			"metrics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "The metrics to export, all by default.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(statsdMetrics, false),
+							Description:  "Name of the metric.",
+						},
+						"stat_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"counter", "gauge", "histogram", "meter", "set", "timer"}, false),
+							Description:  "The StatsD type of the metric.",
+						},
+						"sample_rate": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+							Description:  "Sampling rate of the metric, required for counter and gauge metrics.",
+						},
+						"consumer_identifier": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"consumer_id", "custom_id", "username"}, false),
+							Description:  "How consumers are identified in the per user metrics.",
+						},
+						"service_identifier": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"service_id", "service_name", "service_host", "service_name_or_host"}, false),
+							Description:  "How services are identified in the metric names.",
+						},
+						"workspace_identifier": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"workspace_id", "workspace_name"}, false),
+							Description:  "How workspaces are identified in the per workspace metrics.",
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// syslogSeverities are the syslog severities, from the lowest to the highest.
+var syslogSeverities = []string{"debug", "info", "notice", "warning", "err", "crit", "alert", "emerg"}
+
+// syslogFacilities are the syslog facilities accepted by the syslog plugin.
+var syslogFacilities = []string{
+	"auth", "authpriv", "cron", "daemon", "ftp", "kern", "lpr", "mail", "news", "syslog", "user", "uucp",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+func resourceKongPluginSyslog() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name: "syslog",
+
+		Config: map[string]*schema.Schema{
+			"log_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "info",
+				ValidateFunc: validation.StringInSlice(syslogSeverities, false),
+				Description:  "The minimum severity of the entries sent to syslog.",
+			},
+
+			"successful_severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "info",
+				ValidateFunc: validation.StringInSlice(syslogSeverities, false),
+				Description:  "The severity of the entries of successful requests.",
+			},
+
+			"client_errors_severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "info",
+				ValidateFunc: validation.StringInSlice(syslogSeverities, false),
+				Description:  "The severity of the entries of requests answered with a 4xx status code.",
+			},
+
+			"server_errors_severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "info",
+				ValidateFunc: validation.StringInSlice(syslogSeverities, false),
+				Description:  "The severity of the entries of requests answered with a 5xx status code.",
+			},
+
+			"facility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(syslogFacilities, false),
+				Description:  "The syslog facility of the entries.",
+			},
+
+			"custom_fields_by_lua": customFieldsByLuaSchema(),
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginTCPLog() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "tcp-log",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Host of the log server.",
+			},
+
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the log server.",
+			},
+
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in milliseconds when sending data to the log server.",
+			},
+
+			"keepalive": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How long in milliseconds an idle connection to the log server is kept open.",
+			},
+
+			"tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to connect to the log server over TLS.",
+			},
+
+			"tls_sni": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The server name used for SNI when connecting over TLS.",
+			},
+
+			"custom_fields_by_lua": customFieldsByLuaSchema(),
+		},
+	})
+}
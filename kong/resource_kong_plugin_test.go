@@ -0,0 +1,174 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceKongPluginUpdateScope(t *testing.T) {
+	const (
+		service      = "00000000-0000-4000-8000-0000000000a1"
+		otherService = "00000000-0000-4000-8000-0000000000a2"
+		route        = "00000000-0000-4000-8000-0000000000b1"
+		consumer     = "00000000-0000-4000-8000-0000000000c1"
+	)
+
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+	}{
+		{
+			name:   "added",
+			before: map[string]string{},
+			after:  map[string]string{"service": service},
+		},
+		{
+			name:   "changed",
+			before: map[string]string{"service": service},
+			after:  map[string]string{"service": otherService},
+		},
+		{
+			name:   "moved to another kind of entity",
+			before: map[string]string{"service": service},
+			after:  map[string]string{"route": route},
+		},
+		{
+			name:   "removed",
+			before: map[string]string{"consumer": consumer},
+			after:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kong := newFakeKong(t)
+
+			stored := map[string]interface{}{"name": "key-auth", "enabled": true}
+			attributes := map[string]string{"name": "key-auth", "enabled": "true"}
+			for scope, id := range tt.before {
+				stored[scope] = map[string]interface{}{"id": id}
+				attributes[scope] = id
+			}
+			id := kong.put("plugins", stored)
+
+			r := resourceKongPlugin()
+			d := r.Data(&terraform.InstanceState{ID: id, Attributes: attributes})
+			for _, scope := range []string{"service", "route", "consumer"} {
+				if err := d.Set(scope, tt.after[scope]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expectNoError(t, r.UpdateContext(context.Background(), d, kong.client()))
+
+			body := kong.lastRequestTo(http.MethodPatch, "plugins/"+id).Body
+			for _, scope := range []string{"service", "route", "consumer"} {
+				sent, ok := body[scope]
+				if !ok {
+					t.Errorf("%s not sent, so Kong would keep the previous scope", scope)
+					continue
+				}
+
+				var want interface{}
+				if tt.after[scope] != "" {
+					want = map[string]interface{}{"id": tt.after[scope]}
+				}
+				if !reflect.DeepEqual(sent, want) {
+					t.Errorf("%s sent as %v, want %v", scope, sent, want)
+				}
+
+				if got := d.Get(scope).(string); got != tt.after[scope] {
+					t.Errorf("%s is %q in the state after the update, want %q", scope, got, tt.after[scope])
+				}
+			}
+
+			if d.Id() != id {
+				t.Errorf("ID is %q after the update, want the plugin updated in place as %q", d.Id(), id)
+			}
+		})
+	}
+}
+
+func TestResourceKongPluginReadStrict(t *testing.T) {
+	const service = "00000000-0000-4000-8000-0000000000a1"
+
+	kong := newFakeKong(t)
+	id := kong.put("plugins", map[string]interface{}{
+		"name":           "key-auth",
+		"enabled":        true,
+		"protocols":      []interface{}{"http", "https"},
+		"tags":           nil,
+		"config":         map[string]interface{}{"key_names": []interface{}{"apikey"}},
+		"service":        map[string]interface{}{"id": service},
+		"route":          nil,
+		"consumer":       nil,
+		"consumer_group": nil,
+	})
+
+	r := resourceKongPlugin()
+	d := r.Data(&terraform.InstanceState{ID: id, Attributes: map[string]string{"name": "key-auth"}})
+
+	expectNoError(t, r.ReadContext(context.Background(), d, kong.clientWith(Config{Strict: true})))
+
+	if got := d.Get("service").(string); got != service {
+		t.Errorf("service is %q, want %q", got, service)
+	}
+}
+
+func TestMergePluginConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired string
+		actual  string
+		want    string
+	}{
+		{
+			name:    "defaults filled in by Kong",
+			desired: `{"minute": 10}`,
+			actual:  `{"minute": 10, "hour": null, "policy": "local"}`,
+			want:    `{"minute": 10}`,
+		},
+		{
+			name:    "changed in Kong",
+			desired: `{"minute": 10, "limits": {"sms": {"minute": 5}}}`,
+			actual:  `{"minute": 20, "limits": {"sms": {"minute": 6, "hour": null}}}`,
+			want:    `{"minute": 20, "limits": {"sms": {"minute": 6}}}`,
+		},
+		{
+			name:    "vault reference resolved by Kong",
+			desired: `{"password": "{vault://env/redis-password}", "hosts": ["{vault://env/host}", "b"]}`,
+			actual:  `{"password": "s3cr3t", "hosts": ["a.example.com", "c"]}`,
+			want:    `{"password": "{vault://env/redis-password}", "hosts": ["{vault://env/host}", "c"]}`,
+		},
+		{
+			name:    "dropped by Kong",
+			desired: `{"minute": 10, "renamed": true, "password": "{vault://env/redis-password}"}`,
+			actual:  `{"minute": 10}`,
+			want:    `{"minute": 10}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var desired, actual, want interface{}
+			for _, v := range []struct {
+				json  string
+				value *interface{}
+			}{{tt.desired, &desired}, {tt.actual, &actual}, {tt.want, &want}} {
+				if err := json.Unmarshal([]byte(v.json), v.value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := mergePluginConfig(desired, actual); !reflect.DeepEqual(got, want) {
+				t.Errorf("merged config is %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginUDPLog() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "udp-log",
+		ConfigRequired: true,
+
+		Config: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Host of the log server.",
+			},
+
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the log server.",
+			},
+
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in milliseconds when sending data to the log server.",
+			},
+
+			"custom_fields_by_lua": customFieldsByLuaSchema(),
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceKongPluginVaultAuth : the Enterprise vault-auth plugin, authenticating requests with tokens stored in a
+// HashiCorp Vault
+func resourceKongPluginVaultAuth() *schema.Resource {
+	return resourceKongTypedPlugin(typedPlugin{
+		Name:           "vault-auth",
+		ConfigRequired: true,
+		ToKong:         expandVaultAuthConfig,
+		FromKong:       flattenVaultAuthConfig,
+
+		Config: map[string]*schema.Schema{
+			"vault": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+				Description:  "ID of the vault entity holding the credentials.",
+			},
+
+			"access_token_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "access_token",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the header or query string parameter holding the access token.",
+			},
+
+			"secret_token_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "secret_token",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the header or query string parameter holding the secret token.",
+			},
+
+			"tokens_in_body": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the tokens may also be read from the request body.",
+			},
+
+			"hide_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to remove the tokens from the request before proxying it to the upstream service.",
+			},
+
+			"run_on_preflight": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to authenticate OPTIONS preflight requests.",
+			},
+
+			"anonymous": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID or username of the consumer used when authentication fails. Failed requests are rejected when empty.",
+			},
+		},
+	})
+}
+
+// expandVaultAuthConfig sends the vault as a reference to the entity.
+func expandVaultAuthConfig(config map[string]interface{}) error {
+	if id, ok := config["vault"].(string); ok {
+		config["vault"] = &pluginReference{ID: id}
+	}
+
+	return nil
+}
+
+func flattenVaultAuthConfig(config map[string]interface{}) {
+	if reference, ok := config["vault"].(map[string]interface{}); ok {
+		config["vault"] = reference["id"]
+	}
+}
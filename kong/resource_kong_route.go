@@ -1,12 +1,19 @@
 package kong
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Route : Kong Route request object structure
@@ -20,30 +27,107 @@ type Route struct {
 	Headers                 map[string][]string `json:"headers"`
 	HttpsRedirectStatusCode int                 `json:"https_redirect_status_code,omitempty"`
 	RegexPriority           int                 `json:"regex_priority"`
-	StripPath               bool                `json:"strip_path,omitempty"`
+	StripPath               bool                `json:"strip_path"`
 	PathHandling            string              `json:"path_handling,omitempty"`
-	PreserveHost            bool                `json:"preserve_host,omitempty"`
+	PreserveHost            bool                `json:"preserve_host"`
 	RequestBuffering        bool                `json:"request_buffering"`
 	ResponseBuffering       bool                `json:"response_buffering"`
-	SNIs                    []string            `json:"snis,omitempty"`
-	// Sources                 []string            `json:"sources,omitempty"`
-	// Destinations            []string            `json:"destinations,omitempty"`
-	Tags    []string `json:"tags"`
-	Service Service  `json:"service,omitempty"`
+	SNIs                    []string            `json:"snis"`
+	Sources                 []routeEndpoint     `json:"sources"`
+	Destinations            []routeEndpoint     `json:"destinations"`
+	Expression              string              `json:"expression,omitempty"`
+	Priority                int                 `json:"priority,omitempty"`
+	Tags                    []string            `json:"tags"`
+	Service                 *pluginReference    `json:"service,omitempty"`
+	UpdatedAt               int                 `json:"updated_at,omitempty"`
+
+	// clearExpression sends expression and priority as null and 0, moving the Route back to the traditional matching
+	// attributes.
+	clearExpression bool
 }
 
+// MarshalJSON sends expression and priority, which Kong only knows with the expressions router, when the Route has an
+// expression, or when clearing it on update. They are left out otherwise.
+func (r Route) MarshalJSON() ([]byte, error) {
+	type route Route
+
+	body := struct {
+		route
+		Expression interface{} `json:"expression,omitempty"`
+		Priority   interface{} `json:"priority,omitempty"`
+	}{route: route(r)}
+
+	if r.Expression != "" {
+		body.Expression = r.Expression
+		body.Priority = r.Priority
+	} else if r.clearExpression {
+		body.Expression = json.RawMessage("null")
+		body.Priority = 0
+	}
+
+	return json.Marshal(body)
+}
+
+// routeEndpoint : IP and/or port of the source or destination of a connection matched by a stream route
+type routeEndpoint struct {
+	IP   string `json:"ip,omitempty"`
+	Port int    `json:"port,omitempty"`
+}
+
+// routeProtocolGroups : protocols a route can match, grouped as Kong only accepts protocols of the same group on a route
+var routeProtocolGroups = [][]string{
+	{"http", "https"},
+	{"grpc", "grpcs"},
+	{"ws", "wss"},
+	{"tcp", "tls", "udp"},
+	{"tls_passthrough"},
+}
+
+// routeProtocols : every protocol a route can match
+var routeProtocols = func() []string {
+	var protocols []string
+	for _, group := range routeProtocolGroups {
+		protocols = append(protocols, group...)
+	}
+	return protocols
+}()
+
+// routeSNIProtocols : protocols for which a route can match on the SNI of the TLS handshake
+var routeSNIProtocols = []string{"https", "grpcs", "tls", "tls_passthrough"}
+
+// routeStreamProtocols : protocols for which a route can match on the sources and destinations of a connection
+var routeStreamProtocols = []string{"tcp", "tls", "tls_passthrough", "udp"}
+
 func resourceKongRoute() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongRouteCreate,
-		Read:   resourceKongRouteRead,
-		Update: resourceKongRouteUpdate,
-		Delete: resourceKongRouteDelete,
+		CreateContext: resourceKongRouteCreate,
+		ReadContext:   resourceKongRouteRead,
+		UpdateContext: resourceKongRouteUpdate,
+		DeleteContext: resourceKongRouteDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importNamedEntity("Route", "routes", "name"),
 		},
 
+		CustomizeDiff: customdiff.All(
+			computeUpdatedAtOnChange,
+			validateRouteProtocols,
+			validateRouteStreamAttributes,
+			customdiff.ComputedIf("service", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.HasChange("service_name") && d.Get("service_name").(string) != ""
+			}),
+			validateReferences(entityReference{attribute: "service", collection: "services", entity: "Service"}),
+		),
+
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the Route belongs to. Defaults to the default workspace.",
+			},
 
 			"name": {
 				Type:        schema.TypeString,
@@ -53,21 +137,22 @@ func resourceKongRoute() *schema.Resource {
 			},
 
 			"protocols": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validateOneOf(routeProtocols...)},
 				Required:    true,
+				MinItems:    1,
 				Description: "A list of the protocols this Route should allow. By default it is [\"http\", \"https\"], which means that the Route accepts both. When set to [\"https\"], HTTP requests are answered with a request to upgrade to HTTPS.",
 			},
 
 			"methods": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringMatch(httpMethodRegexp, "must be an uppercase HTTP method")},
 				Optional:    true,
 				Description: "A list of HTTP methods that match this Route. For example: [\"GET\", \"POST\"]. At least one of hosts, paths, or methods must be set.",
 			},
 
 			"hosts": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "A list of domain names that match this Route. For example: example.com. At least one of hosts, paths, or methods must be set.",
@@ -88,34 +173,42 @@ func resourceKongRoute() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateRouteHeaderName,
+							Description:  "The name of the header, matched case-insensitively. The Host header can't be used, see hosts instead.",
 						},
 						"values": {
 							Type:     schema.TypeList,
 							Required: true,
+							MinItems: 1,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
+							Description: "The values the header may have for this Route to match. A single value starting with ~* is matched as a regex.",
 						},
 					},
 				},
-				Description: "One or more lists of values indexed by header name that will cause this Route to match if present in the request.",
+				Description: "One or more lists of values indexed by header name that will cause this Route to match if present in the request. Sent to Kong as the headers map.",
 			},
 
 			"https_redirect_status_code": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     426,
-				Description: "The status code Kong responds with when all properties of a Route match except the protocol i.e. if the protocol of the request is HTTP instead of HTTPS",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      426,
+				ValidateFunc: validation.IntInSlice([]int{426, 301, 302, 307, 308}),
+				Description:  "The status code Kong responds with when all properties of a Route match except the protocol i.e. if the protocol of the request is HTTP instead of HTTPS. One of 426, 301, 302, 307 or 308.",
 			},
 
 			"regex_priority": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-				Description: "A number used to choose which route resolves a given request when several routes match it using regexes simultaneously.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(math.MinInt32, math.MaxInt32),
+				Description:  "A number used to choose which route resolves a given request when several routes match it using regexes simultaneously. The Route with the highest number is used.",
 			},
+
 			"strip_path": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -124,10 +217,11 @@ func resourceKongRoute() *schema.Resource {
 			},
 
 			"path_handling": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "v0",
-				Description: "Controls how the Service path, Route path and requested path are combined when sending a request to the upstream",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "v0",
+				ValidateFunc: validation.StringInSlice([]string{"v0", "v1"}, false),
+				Description:  "Controls how the Service path, Route path and requested path are combined when sending a request to the upstream. One of v0 or v1.",
 			},
 
 			"preserve_host": {
@@ -152,105 +246,123 @@ func resourceKongRoute() *schema.Resource {
 			},
 
 			"snis": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
 				Optional:    true,
-				Default:     nil,
-				Description: "A list of SNIs that match this Route when using stream routing.",
+				Description: "A list of SNIs that match this Route when using https, grpcs, tls or tls_passthrough.",
 			},
 
-			// "sources": {
-			// 	Type: schema.TypeSet,
-			// 	Elem: &schema.Resource{
-			// 		Schema: map[string]*schema.Schema{
-			// 			"ip": {
-			// 				Type:     schema.TypeString,
-			// 				Optional: true,
-			// 			},
-			// 			"port": {
-			// 				Type:     schema.TypeInt,
-			// 				Optional: true,
-			// 			},
-			// 		},
-			// 	},
-			// 	Optional:    true,
-			// 	Description: "A list of IP sources of incoming connections that match this Route when using stream routing",
-			// },
-
-			// "destinations": {
-			// 	Type: schema.TypeSet,
-			// 	Elem: &schema.Resource{
-			// 		Schema: map[string]*schema.Schema{
-			// 			"ip": {
-			// 				Type:     schema.TypeString,
-			// 				Optional: true,
-			// 			},
-			// 			"port": {
-			// 				Type:     schema.TypeInt,
-			// 				Optional: true,
-			// 			},
-			// 		},
-			// 	},
-			// 	Optional:    true,
-			// 	Description: "A list of IP destinations of incoming connections that match this Route when using stream routing",
-			// },
+			"sources": {
+				Type:        schema.TypeSet,
+				Elem:        routeEndpointSchema(),
+				Optional:    true,
+				Description: "A list of IP sources of incoming connections that match this Route when using stream routing.",
+			},
+
+			"destinations": {
+				Type:        schema.TypeSet,
+				Elem:        routeEndpointSchema(),
+				Optional:    true,
+				Description: "A list of IP destinations of incoming connections that match this Route when using stream routing.",
+			},
+
+			"expression": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateRouteExpression,
+				ConflictsWith: []string{"methods", "hosts", "paths", "header", "snis", "sources", "destinations"},
+				Description:   "The expression this Route matches requests with, e.g. http.path ^= \"/foo\" && http.method == \"GET\". Requires Kong 3.0 or later with router_flavor set to expressions.",
+			},
+
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				RequiredWith: []string{"expression"},
+				Description:  "A number used to choose which Route resolves a given request when several expressions match it. The Route with the highest number is used.",
+			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
 
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the Route was last updated.",
+			},
+
 			"service": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The Service this Route is associated to. This is where the Route proxies traffic to.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"service", "service_name"},
+				ValidateFunc: validateUUIDReference,
+				Description:  "The ID of the Service this Route is associated to. This is where the Route proxies traffic to.",
+			},
+
+			"service_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The name of the Service this Route is associated to, resolved to its ID on apply. Use it instead of service for Services managed outside of this configuration.",
 			},
 		},
 	}
 }
 
-func resourceKongRouteCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	route := getRouteFromResourceData(d)
 
+	if name := d.Get("service_name").(string); name != "" {
+		id, err := lookupServiceID(sling, d.Get("workspace").(string), name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		route.Service = &pluginReference{ID: id}
+	}
+
 	createdRoute := new(Route)
-	response, error := sling.New().BodyJSON(route).Post("routes/").ReceiveSuccess(createdRoute)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(route).Post("routes/").ReceiveSuccess(createdRoute)
 
 	if error != nil {
-		return fmt.Errorf("error while creating Route: " + error.Error())
+		return diag.Errorf("error while creating Route: %s", error.Error())
 	}
 
 	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this route")
+		return diag.Errorf("409 Conflict - use terraform import to manage this route")
 	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
 	setRouteToResourceData(d, createdRoute)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongRouteRead)
 }
 
-func resourceKongRouteRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	id := d.Id()
 	route := new(Route)
 
-	response, error := sling.New().Path("routes/").Get(id).ReceiveSuccess(route)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "routes/"+pathSegment(id), "routes", id, route)
 
 	if error != nil {
-		return fmt.Errorf("error while updating Route" + error.Error())
+		return diag.Errorf("error while updating Route: %s", error.Error())
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
 	setRouteToResourceData(d, route)
@@ -258,52 +370,70 @@ func resourceKongRouteRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongRouteUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "Route", "routes/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	route := getRouteFromResourceData(d)
 
+	if name := d.Get("service_name").(string); name != "" {
+		id, err := lookupServiceID(sling, d.Get("workspace").(string), name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		route.Service = &pluginReference{ID: id}
+	}
+
 	updatedRoute := new(Route)
 
-	response, error := sling.New().BodyJSON(route).Patch("routes/").Path(route.ID).ReceiveSuccess(updatedRoute)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(route).Patch("routes/").Path(pathSegment(route.ID)).ReceiveSuccess(updatedRoute)
 
 	if error != nil {
-		return fmt.Errorf("error while updating Route" + error.Error())
+		return diag.Errorf("error while updating Route: %s", error.Error())
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
 	setRouteToResourceData(d, updatedRoute)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongRouteRead)...)
 }
 
-func resourceKongRouteDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "Route", "routes/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	id := d.Id()
 
-	response, error := sling.New().Delete("routes/").Path(id).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Delete("routes/").Path(pathSegment(id)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting Route" + error.Error())
+		return diag.Errorf("error while deleting Route: %s", error.Error())
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getRouteFromResourceData(d *schema.ResourceData) *Route {
 	route := &Route{
 		ID:                      d.Id(),
 		Name:                    d.Get("name").(string),
-		Protocols:               helper.ConvertInterfaceArrToStrings(d.Get("protocols").([]interface{})),
-		Methods:                 helper.ConvertInterfaceArrToStrings(d.Get("methods").([]interface{})),
-		Hosts:                   helper.ConvertInterfaceArrToStrings(d.Get("hosts").([]interface{})),
+		Protocols:               helper.ConvertInterfaceArrToStrings(d.Get("protocols").(*schema.Set).List()),
+		Methods:                 helper.ConvertInterfaceArrToStrings(d.Get("methods").(*schema.Set).List()),
+		Hosts:                   helper.ConvertInterfaceArrToStrings(d.Get("hosts").(*schema.Set).List()),
 		Paths:                   helper.ConvertInterfaceArrToStrings(d.Get("paths").([]interface{})),
 		Headers:                 readMapStringArrayFromResource(d, "header"),
 		HttpsRedirectStatusCode: d.Get("https_redirect_status_code").(int),
@@ -313,15 +443,31 @@ func getRouteFromResourceData(d *schema.ResourceData) *Route {
 		PreserveHost:            d.Get("preserve_host").(bool),
 		RequestBuffering:        d.Get("request_buffering").(bool),
 		ResponseBuffering:       d.Get("response_buffering").(bool),
-		SNIs:                    helper.ConvertInterfaceArrToStrings(d.Get("snis").([]interface{})),
-		// Sources:                 helper.ConvertInterfaceArrToStrings(d.Get("sources").([]interface{})),
-		// Destinations:            helper.ConvertInterfaceArrToStrings(d.Get("destinations").([]interface{})),
-		Tags: helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
-		Service: Service{
+		Sources:                 expandRouteEndpoints(d.Get("sources").(*schema.Set)),
+		Destinations:            expandRouteEndpoints(d.Get("destinations").(*schema.Set)),
+		Tags:                    helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
+		Service: &pluginReference{
 			ID: d.Get("service").(string),
 		},
 	}
 
+	// The traditional matching attributes are left out for the expressions router.
+	if expression := d.Get("expression").(string); expression != "" {
+		route.Expression = expression
+		route.Priority = d.Get("priority").(int)
+		route.Methods = nil
+		route.Hosts = nil
+		route.Paths = nil
+		route.Headers = nil
+	} else if old, _ := d.GetChange("expression"); old.(string) != "" {
+		route.clearExpression = true
+	}
+
+	// Stream routing attributes are sent as null when not set, as Kong rejects them for the protocols not using them.
+	if snis := helper.ConvertInterfaceArrToStrings(d.Get("snis").(*schema.Set).List()); len(snis) > 0 {
+		route.SNIs = snis
+	}
+
 	return route
 }
 
@@ -332,7 +478,7 @@ func setRouteToResourceData(d *schema.ResourceData, route *Route) {
 	d.Set("methods", route.Methods)
 	d.Set("hosts", route.Hosts)
 	d.Set("paths", route.Paths)
-	d.Set("headers", route.Headers)
+	d.Set("header", flattenRouteHeaders(d.Get("header").(*schema.Set), route.Headers))
 	d.Set("https_redirect_status_code", route.HttpsRedirectStatusCode)
 	d.Set("regex_priority", route.RegexPriority)
 	d.Set("strip_path", route.StripPath)
@@ -341,10 +487,198 @@ func setRouteToResourceData(d *schema.ResourceData, route *Route) {
 	d.Set("request_buffering", route.RequestBuffering)
 	d.Set("response_buffering", route.ResponseBuffering)
 	d.Set("snis", route.SNIs)
-	// d.Set("sources", route.Sources)
-	// d.Set("destinations", route.Destinations)
+	d.Set("sources", flattenRouteEndpoints(route.Sources))
+	d.Set("destinations", flattenRouteEndpoints(route.Destinations))
+	d.Set("expression", route.Expression)
+	d.Set("priority", route.Priority)
 	d.Set("tags", route.Tags)
-	d.Set("service", route.Service.ID)
+	d.Set("updated_at", route.UpdatedAt)
+	if route.Service != nil {
+		d.Set("service", route.Service.ID)
+	}
+}
+
+// lookupServiceID returns the ID of the Service with the given name in the workspace.
+func lookupServiceID(client *Client, workspace string, name string) (string, error) {
+	service := new(Service)
+
+	response, err := client.Workspace(workspace).Path("services/").Get(pathSegment(name)).ReceiveSuccess(service)
+	if err != nil {
+		return "", fmt.Errorf("error while looking up Service %s: %s", name, err)
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("no Service named %s was found, check service_name", name)
+	} else if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	return service.ID, nil
+}
+
+func routeEndpointSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				Description:  "The IP address or CIDR range of the connection.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "The port of the connection.",
+			},
+		},
+	}
+}
+
+func expandRouteEndpoints(set *schema.Set) []routeEndpoint {
+	if set.Len() == 0 {
+		return nil
+	}
+
+	endpoints := make([]routeEndpoint, 0, set.Len())
+	for _, item := range set.List() {
+		m := item.(map[string]interface{})
+		endpoints = append(endpoints, routeEndpoint{
+			IP:   m["ip"].(string),
+			Port: m["port"].(int),
+		})
+	}
+
+	return endpoints
+}
+
+func flattenRouteEndpoints(endpoints []routeEndpoint) []interface{} {
+	items := make([]interface{}, 0, len(endpoints))
+	for _, e := range endpoints {
+		items = append(items, map[string]interface{}{
+			"ip":   e.IP,
+			"port": e.Port,
+		})
+	}
+
+	return items
+}
+
+// validateRouteProtocols checks that protocols are not mixed across groups and that the attributes gRPC routes don't
+// support are left unset.
+func validateRouteProtocols(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("protocols") {
+		return nil
+	}
+
+	protocols := helper.ConvertInterfaceArrToStrings(d.Get("protocols").(*schema.Set).List())
+
+	var group []string
+	for _, p := range protocols {
+		for _, g := range routeProtocolGroups {
+			if !anyRouteProtocolIn([]string{p}, g) {
+				continue
+			}
+			if group != nil && !anyRouteProtocolIn([]string{p}, group) {
+				return fmt.Errorf("protocols can't mix %s with %s, use a separate Route", strings.Join(group, "/"), p)
+			}
+			group = g
+		}
+	}
+
+	if anyRouteProtocolIn(protocols, []string{"grpc", "grpcs"}) {
+		if d.Get("strip_path").(bool) {
+			return fmt.Errorf("strip_path must be set to false when protocols are grpc or grpcs, as gRPC method paths can't be stripped")
+		}
+		if d.NewValueKnown("methods") && d.Get("methods").(*schema.Set).Len() > 0 {
+			return fmt.Errorf("methods can't be set when protocols are grpc or grpcs, as every gRPC call is a POST request")
+		}
+	}
+
+	return nil
+}
+
+// validateRouteStreamAttributes checks that snis, sources and destinations are only used with the protocols matching
+// on them, and that every source and destination has an IP or a port.
+func validateRouteStreamAttributes(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("protocols") {
+		return nil
+	}
+
+	protocols := helper.ConvertInterfaceArrToStrings(d.Get("protocols").(*schema.Set).List())
+
+	if d.NewValueKnown("snis") && d.Get("snis").(*schema.Set).Len() > 0 && !anyRouteProtocolIn(protocols, routeSNIProtocols) {
+		return fmt.Errorf("snis can only be set when protocols include one of %s", strings.Join(routeSNIProtocols, ", "))
+	}
+
+	for _, k := range []string{"sources", "destinations"} {
+		if !d.NewValueKnown(k) {
+			continue
+		}
+
+		set := d.Get(k).(*schema.Set)
+		if set.Len() > 0 && !anyRouteProtocolIn(protocols, routeStreamProtocols) {
+			return fmt.Errorf("%s can only be set when protocols include one of %s", k, strings.Join(routeStreamProtocols, ", "))
+		}
+
+		for _, item := range set.List() {
+			m := item.(map[string]interface{})
+			if m["ip"].(string) == "" && m["port"].(int) == 0 {
+				return fmt.Errorf("every item of %s must have an ip or a port", k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func anyRouteProtocolIn(protocols []string, allowed []string) bool {
+	for _, p := range protocols {
+		for _, a := range allowed {
+			if p == a {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// flattenRouteHeaders converts the headers map of a route into header blocks. Kong returns header names in lower case,
+// so the names are kept as written in the configuration when they only differ in case.
+func flattenRouteHeaders(current *schema.Set, headers map[string][]string) []interface{} {
+	names := make(map[string]string)
+	for _, item := range current.List() {
+		name := item.(map[string]interface{})["name"].(string)
+		names[strings.ToLower(name)] = name
+	}
+
+	blocks := make([]interface{}, 0, len(headers))
+	for name, values := range headers {
+		if n, ok := names[strings.ToLower(name)]; ok {
+			name = n
+		}
+
+		blocks = append(blocks, map[string]interface{}{
+			"name":   name,
+			"values": values,
+		})
+	}
+
+	return blocks
+}
+
+// validateRouteHeaderName rejects the Host header, which Kong only matches through the hosts of a route.
+func validateRouteHeaderName(v interface{}, k string) ([]string, []error) {
+	name := v.(string)
+	if name == "" {
+		return nil, []error{fmt.Errorf("%s must not be empty", k)}
+	}
+	if strings.EqualFold(name, "host") {
+		return nil, []error{fmt.Errorf("%s can't be the Host header, use hosts instead", k)}
+	}
+
+	return nil, nil
 }
 
 func readMapStringArrayFromResource(d *schema.ResourceData, key string) map[string][]string {
@@ -0,0 +1,78 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceKongRouteCreateTraditional(t *testing.T) {
+	kong := newFakeKong(t)
+	service := kong.put("services", map[string]interface{}{"name": "example", "host": "example.com"})
+	d := schema.TestResourceDataRaw(t, resourceKongRoute().Schema, map[string]interface{}{
+		"service": service,
+		"paths":   []interface{}{"/api"},
+	})
+
+	expectNoError(t, resourceKongRouteCreate(context.Background(), d, kong.client()))
+
+	body := kong.lastRequestTo(http.MethodPost, "routes").Body
+	for _, k := range []string{"expression", "priority"} {
+		if v, ok := body[k]; ok {
+			t.Errorf("%s sent as %v, which Kong rejects without the expressions router", k, v)
+		}
+	}
+}
+
+func TestResourceKongRouteUpdateClearExpression(t *testing.T) {
+	kong := newFakeKong(t)
+	service := kong.put("services", map[string]interface{}{"name": "example", "host": "example.com"})
+	id := kong.put("routes", map[string]interface{}{
+		"protocols":  []interface{}{"http", "https"},
+		"expression": `http.path ^= "/api"`,
+		"priority":   5,
+		"service":    map[string]interface{}{"id": service},
+	})
+
+	r := resourceKongRoute()
+	d := r.Data(&terraform.InstanceState{ID: id, Attributes: map[string]string{
+		"service":     service,
+		"protocols.#": "2",
+		"protocols.0": "http",
+		"protocols.1": "https",
+		"expression":  `http.path ^= "/api"`,
+		"priority":    "5",
+	}})
+	for k, v := range map[string]interface{}{"expression": "", "priority": 0, "paths": []interface{}{"/api"}} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectNoError(t, resourceKongRouteUpdate(context.Background(), d, kong.client()))
+
+	body := kong.lastRequestTo(http.MethodPatch, "routes/"+id).Body
+	if expression, ok := body["expression"]; !ok || expression != nil {
+		t.Errorf("expression sent as %v, want null to clear it", expression)
+	}
+	if priority, ok := body["priority"]; !ok || priority != float64(0) {
+		t.Errorf("priority sent as %v, want 0 to reset it", priority)
+	}
+
+	if expression := d.Get("expression").(string); expression != "" {
+		t.Errorf("expression is %q after the update, want it cleared", expression)
+	}
+}
+
+func TestResourceKongRouteMethods(t *testing.T) {
+	methods := resourceKongRoute().Schema["methods"].Elem.(*schema.Schema)
+
+	for method, valid := range map[string]bool{"GET": true, "PURGE": true, "PROPFIND": true, "get": false, "": false} {
+		if _, errs := methods.ValidateFunc(method, "methods"); (len(errs) == 0) != valid {
+			t.Errorf("validating %q returned %v", method, errs)
+		}
+	}
+}
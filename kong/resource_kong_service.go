@@ -1,46 +1,79 @@
 package kong
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// serviceProtocols : protocols Kong can use to communicate with the upstream of a service
+var serviceProtocols = []string{"http", "https", "grpc", "grpcs", "tcp", "tls", "udp", "ws", "wss"}
+
 // Service : Kong Service request object structure
 type Service struct {
-	ID                string      `json:"id,omitempty"`
-	Name              string      `json:"name,omitempty"`
-	Retries           int         `json:"retries,omitempty"`
-	Protocol          string      `json:"protocol,omitempty"`
-	Host              string      `json:"host,omitempty"`
-	Port              int         `json:"port,omitempty"`
-	Path              string      `json:"path,omitempty"`
-	ConnectTimeout    int         `json:"connect_timeout,omitempty"`
-	WriteTimeout      int         `json:"write_timeout,omitempty"`
-	ReadTimeout       int         `json:"read_timeout,omitempty"`
-	Tags              []string    `json:"tags"`
-	ClientCertificate Certificate `json:"-"`                          // TO DO: add if statement which assign value only if Protocol is HTTPS
-	TlsVerify         bool        `json:"tls_verify,omitempty"`       //
-	TlsVerifyDepth    int         `json:"tls_verify_depth,omitempty"` //
-	CACertificates    []string    `json:"-"`                          //
-	Enabled           bool        `json:"enabled"`
+	ID                string           `json:"id,omitempty"`
+	Name              string           `json:"name,omitempty"`
+	Retries           int              `json:"retries,omitempty"`
+	Protocol          string           `json:"protocol,omitempty"`
+	Host              string           `json:"host,omitempty"`
+	Port              int              `json:"port,omitempty"`
+	Path              *string          `json:"path"`
+	ConnectTimeout    int              `json:"connect_timeout,omitempty"`
+	WriteTimeout      int              `json:"write_timeout,omitempty"`
+	ReadTimeout       int              `json:"read_timeout,omitempty"`
+	Tags              []string         `json:"tags"`
+	ClientCertificate *pluginReference `json:"client_certificate"`
+	TlsVerify         *bool            `json:"tls_verify"`
+	TlsVerifyDepth    *int             `json:"tls_verify_depth"`
+	CACertificates    []string         `json:"ca_certificates"`
+	Enabled           *bool            `json:"enabled,omitempty"`
+	UpdatedAt         int              `json:"updated_at,omitempty"`
 }
 
 func resourceKongService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongServiceCreate,
-		Read:   resourceKongServiceRead,
-		Update: resourceKongServiceUpdate,
-		Delete: resourceKongServiceDelete,
+		CreateContext: resourceKongServiceCreate,
+		ReadContext:   resourceKongServiceRead,
+		UpdateContext: resourceKongServiceUpdate,
+		DeleteContext: resourceKongServiceDelete,
+
+		Timeouts: resourceTimeouts(20 * time.Minute),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importNamedEntity("Service", "services", "name"),
 		},
 
+		CustomizeDiff: customdiff.All(
+			computeUpdatedAtOnChange,
+			computeServiceURL,
+			preventProtectedReplacement("Service", "workspace"),
+			validateReferences(
+				entityReference{attribute: "client_certificate", collection: "certificates", entity: "certificate"},
+				entityReference{attribute: "ca_certificates", collection: "ca_certificates", entity: "CA certificate"},
+			),
+		),
+
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the Service belongs to. Defaults to the default workspace.",
+			},
+
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -48,31 +81,45 @@ func resourceKongService() *schema.Resource {
 				Description: "The Service name.",
 			},
 
+			"url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsURLWithScheme(serviceProtocols),
+				ConflictsWith: []string{"protocol", "host", "port", "path"},
+				Description:   "Shorthand to set protocol, host, port and path at once, e.g. https://example.com:8443/some_api.",
+			},
+
 			"protocol": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The protocol used to communicate with the upstream. It can be one of http (default) or https.",
-				Default:     "http",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateOneOf(serviceProtocols...),
+				Description:      "The protocol used to communicate with the upstream. It can be one of http (default) or https.",
 			},
 
 			"host": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The host of the upstream server.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"host", "url"},
+				ValidateDiagFunc: validateServiceHost,
+				Description:      "The host of the upstream server, a hostname or an IP address.",
 			},
 
 			"port": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "The upstream server port. Defaults to 80.",
-				Default:     80,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+				Description:      "The upstream server port. Defaults to 80.",
 			},
 
 			"path": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The path to be used in requests to the upstream server. Empty by default.",
-				Default:     "/",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateServicePath,
+				Description:      "The path to be used in requests to the upstream server, left unset by default.",
 			},
 
 			"retries": {
@@ -104,92 +151,109 @@ func resourceKongService() *schema.Resource {
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
 
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the Service was last updated.",
+			},
+
 			"client_certificate": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Certificate to be used as client certificate while TLS handshaking to the upstream server",
-				RequiredWith: []string{"protocol"},
-				Default:      nil,
+				ValidateFunc: validateUUIDReference,
+				Description:  "The ID of the Certificate to be used as client certificate while TLS handshaking to the upstream server.",
 			},
 
 			"tls_verify": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Whether to enable verification of upstream server TLS certificate. If set to null, then the Nginx default is respected",
-				Default:     nil,
+				Description: "Whether to enable verification of upstream server TLS certificate. If not set, then the Nginx default is respected.",
 			},
 
 			"tls_verify_depth": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Maximum depth of chain while verifying Upstream servers TLS certificate. If set to null, then the Nginx default is respected",
-				Default:     nil,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 64),
+				Description:  "Maximum depth of chain while verifying Upstream servers TLS certificate. If not set, then the Nginx default is respected.",
 			},
 
 			"ca_certificates": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateUUIDReference},
 				Optional:    true,
-				Description: "Array of CA Certificate object UUIDs that are used to build the trust store while verifying upstream servers TLS certificate",
-				Default:     nil,
+				Description: "Array of CA Certificate object UUIDs that are used to build the trust store while verifying upstream servers TLS certificate.",
 			},
 
 			"enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Whether the Service is active",
+				Description: "Whether the Service is active. Disabling a Service keeps its Routes and Plugins but stops proxying its traffic. Requires Kong 2.7 or later to be set to false.",
 				Default:     true,
 			},
+
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the Routes of the Service before deleting it. Kong otherwise rejects deleting a Service which still has Routes.",
+			},
+
+			"protect": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to refuse deleting or replacing the Service, e.g. on terraform destroy, until protect is set back to false and applied.",
+			},
 		},
 	}
 }
 
-func resourceKongServiceCreate(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
+func resourceKongServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*Client).WithContext(ctx)
 
 	service := getServiceFromResourceData(d)
 
 	createdService := new(Service)
-	response, e := s.New().BodyJSON(service).Post("services/").ReceiveSuccess(createdService)
+	response, e := s.Workspace(d.Get("workspace").(string)).BodyJSON(service).Post("services/").ReceiveSuccess(createdService)
 
 	if e != nil {
-		return fmt.Errorf("error while creating Service: " + e.Error())
+		return diag.Errorf("error while creating Service: %s", e.Error())
 	}
 
 	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this service")
+		return diag.Errorf("409 Conflict - use terraform import to manage this service")
 	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
 	setServiceToResourceData(d, createdService)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongServiceRead)
 }
 
-func resourceKongServiceRead(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
+func resourceKongServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*Client).WithContext(ctx)
 
 	id := d.Id()
 	service := new(Service)
 
-	response, e := s.New().Path("services/").Get(id).ReceiveSuccess(service)
+	response, e := s.readEntity(ctx, d.Get("workspace").(string), "services/"+pathSegment(id), "services", id, service)
 
 	if e != nil {
-		return fmt.Errorf("error while updating Service" + e.Error())
+		return diag.Errorf("error while updating Service: %s", e.Error())
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
 	setServiceToResourceData(d, service)
@@ -197,43 +261,65 @@ func resourceKongServiceRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongServiceUpdate(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
+func resourceKongServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, s, "Service", "services/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	service := getServiceFromResourceData(d)
 
 	updatedService := new(Service)
 
-	response, e := s.New().BodyJSON(service).Patch("services/").Path(service.ID).ReceiveSuccess(updatedService)
+	response, e := s.Workspace(d.Get("workspace").(string)).BodyJSON(service).Patch("services/").Path(pathSegment(service.ID)).ReceiveSuccess(updatedService)
 
 	if e != nil {
-		return fmt.Errorf("error while updating Service" + e.Error())
+		return diag.Errorf("error while updating Service: %s", e.Error())
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
 	setServiceToResourceData(d, updatedService)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongServiceRead)...)
 }
 
-func resourceKongServiceDelete(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
+func resourceKongServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := checkNotProtected(d, "Service"); diags.HasError() {
+		return diags
+	}
+
+	s := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, s, "Service", "services/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	id := d.Id()
 
-	response, e := s.New().Delete("services/").Path(id).ReceiveSuccess(nil)
+	if d.Get("force_destroy").(bool) {
+		if err := deleteDependents(s.Workspace(d.Get("workspace").(string)), s.pageSize, "services/"+pathSegment(id)+"/routes"); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := checkNoDependents(s.Workspace(d.Get("workspace").(string)), s.pageSize, "services/"+pathSegment(id)+"/routes", "Service "+id, "Routes"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, e := s.Workspace(d.Get("workspace").(string)).Delete("services/").Path(pathSegment(id)).ReceiveSuccess(nil)
 	if e != nil {
-		return fmt.Errorf("error while deleting Service" + e.Error())
+		return diag.Errorf("error while deleting Service: %s", e.Error())
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("unexpected status code received: %s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getServiceFromResourceData(d *schema.ResourceData) *Service {
@@ -244,18 +330,39 @@ func getServiceFromResourceData(d *schema.ResourceData) *Service {
 		Protocol:       d.Get("protocol").(string),
 		Host:           d.Get("host").(string),
 		Port:           d.Get("port").(int),
-		Path:           d.Get("path").(string),
 		ConnectTimeout: d.Get("connect_timeout").(int),
 		WriteTimeout:   d.Get("write_timeout").(int),
 		ReadTimeout:    d.Get("read_timeout").(int),
-		Tags:           helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
-		ClientCertificate: Certificate{
-			ID: d.Get("client_certificate").(string),
-		},
-		TlsVerify:      d.Get("tls_verify").(bool),
-		TlsVerifyDepth: d.Get("tls_verify_depth").(int),
-		CACertificates: helper.ConvertInterfaceArrToStrings(d.Get("ca_certificates").([]interface{})),
-		Enabled:        d.Get("enabled").(bool),
+		Tags:           helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
+		CACertificates: helper.ConvertInterfaceArrToStrings(d.Get("ca_certificates").(*schema.Set).List()),
+	}
+
+	if path := d.Get("path").(string); path != "" {
+		service.Path = &path
+	}
+
+	if id := d.Get("client_certificate").(string); id != "" {
+		service.ClientCertificate = &pluginReference{ID: id}
+	}
+
+	// Unset TLS verification attributes are sent as null so that Kong falls back to the Nginx defaults.
+	raw := d.GetRawConfig()
+	if rawConfigIsSet(rawConfigAttribute(raw, "tls_verify")) {
+		tlsVerify := d.Get("tls_verify").(bool)
+		service.TlsVerify = &tlsVerify
+	}
+	if rawConfigIsSet(rawConfigAttribute(raw, "tls_verify_depth")) {
+		tlsVerifyDepth := d.Get("tls_verify_depth").(int)
+		service.TlsVerifyDepth = &tlsVerifyDepth
+	}
+
+	// enabled is only sent when needed, so that services can still be managed on Kong versions older than 2.7.
+	if enabled := d.Get("enabled").(bool); !enabled || (d.Id() != "" && d.HasChange("enabled")) {
+		service.Enabled = &enabled
+	}
+
+	if len(service.CACertificates) == 0 {
+		service.CACertificates = nil
 	}
 
 	return service
@@ -268,14 +375,172 @@ func setServiceToResourceData(d *schema.ResourceData, service *Service) {
 	_ = d.Set("protocol", service.Protocol)
 	_ = d.Set("host", service.Host)
 	_ = d.Set("port", service.Port)
-	_ = d.Set("path", service.Path)
+	if service.Path != nil {
+		_ = d.Set("path", *service.Path)
+	} else {
+		_ = d.Set("path", "")
+	}
 	_ = d.Set("connect_timeout", service.ConnectTimeout)
 	_ = d.Set("write_timeout", service.WriteTimeout)
 	_ = d.Set("read_timeout", service.ReadTimeout)
 	_ = d.Set("tags", service.Tags)
-	_ = d.Set("client_certificate", service.ClientCertificate)
-	_ = d.Set("tls_verify", service.TlsVerify)
-	_ = d.Set("tls_verify_depth", service.TlsVerifyDepth)
+	_ = d.Set("updated_at", service.UpdatedAt)
+	if service.ClientCertificate != nil {
+		_ = d.Set("client_certificate", service.ClientCertificate.ID)
+	} else {
+		_ = d.Set("client_certificate", "")
+	}
+	if service.TlsVerify != nil {
+		_ = d.Set("tls_verify", *service.TlsVerify)
+	} else {
+		_ = d.Set("tls_verify", nil)
+	}
+	if service.TlsVerifyDepth != nil {
+		_ = d.Set("tls_verify_depth", *service.TlsVerifyDepth)
+	} else {
+		_ = d.Set("tls_verify_depth", nil)
+	}
 	_ = d.Set("ca_certificates", service.CACertificates)
-	_ = d.Set("enabled", service.Enabled)
+	if service.Enabled != nil {
+		_ = d.Set("enabled", *service.Enabled)
+	} else {
+		_ = d.Set("enabled", true)
+	}
+}
+
+// hostnameRegexp : hostnames made of labels of letters, digits, hyphens and underscores, with an optional trailing dot
+var hostnameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?)*\.?$`)
+
+// validateServiceHost accepts hostnames and IP addresses, pointing to url or port for the common mistakes.
+func validateServiceHost(v interface{}, path cty.Path) diag.Diagnostics {
+	host := v.(string)
+
+	switch {
+	case strings.Contains(host, "://"):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid host %q", host),
+			Detail:        "host must not include a scheme, use url to set protocol, host, port and path at once.",
+			AttributePath: path,
+		}}
+	case net.ParseIP(host) != nil:
+		return nil
+	case strings.Contains(host, ":") || strings.Contains(host, "/"):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid host %q", host),
+			Detail:        "host must be a hostname or an IP address only, set the port and path with the port and path attributes.",
+			AttributePath: path,
+		}}
+	case len(host) > 253 || !hostnameRegexp.MatchString(host):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid host %q", host),
+			Detail:        "host must be a valid hostname or IP address.",
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// validateServicePath accepts paths starting with / and without empty segments, as Kong does.
+func validateServicePath(v interface{}, path cty.Path) diag.Diagnostics {
+	p := v.(string)
+
+	switch {
+	case p == "":
+		return nil
+	case !strings.HasPrefix(p, "/"):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid path %q", p),
+			Detail:        "path must start with /.",
+			AttributePath: path,
+		}}
+	case strings.Contains(p, "//"):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid path %q", p),
+			Detail:        "path must not have empty segments.",
+			AttributePath: path,
+		}}
+	case strings.ContainsAny(p, " ?#"):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid path %q", p),
+			Detail:        "path must not contain spaces, a query string or a fragment.",
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// serviceDefaults : values of the upstream attributes when neither they nor url are set. path has no default, Kong
+// leaving it null.
+var serviceDefaults = map[string]interface{}{
+	"protocol": "http",
+	"port":     80,
+}
+
+// computeServiceURL splits the url shorthand into protocol, host, port and path the same way Kong does, so that the
+// plan shows the upstream the service will point to. Without url, the upstream attributes left out of the
+// configuration of a new service get their defaults.
+func computeServiceURL(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown("url") && d.Get("url").(string) == "" {
+		if d.Id() != "" {
+			return nil
+		}
+
+		for k, v := range serviceDefaults {
+			if _, ok := d.GetOk(k); !ok {
+				if err := d.SetNew(k, v); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if !d.NewValueKnown("url") {
+		for _, k := range []string{"protocol", "host", "port", "path"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	u, err := url.Parse(d.Get("url").(string))
+	if err != nil {
+		return fmt.Errorf("invalid url: %s", err)
+	}
+
+	port := 80
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if p := u.Port(); p != "" {
+		if port, err = strconv.Atoi(p); err != nil {
+			return fmt.Errorf("invalid url port %q: %s", p, err)
+		}
+	}
+
+	values := map[string]interface{}{
+		"protocol": u.Scheme,
+		"host":     u.Hostname(),
+		"port":     port,
+		"path":     u.EscapedPath(),
+	}
+
+	for k, v := range values {
+		if err := d.SetNew(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
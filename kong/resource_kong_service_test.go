@@ -0,0 +1,190 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// diagnosticsError returns the summaries of the errors of diags, failing the test when there are none.
+func diagnosticsError(t *testing.T, diags diag.Diagnostics) string {
+	t.Helper()
+
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			summaries = append(summaries, d.Summary)
+		}
+	}
+
+	if len(summaries) == 0 {
+		t.Fatal("expected an error, got none")
+	}
+
+	return strings.Join(summaries, "; ")
+}
+
+// expectNoError fails the test when diags hold an error.
+func expectNoError(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestResourceKongServiceCreate(t *testing.T) {
+	kong := newFakeKong(t)
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"name": "example",
+		"host": "example.com",
+		"path": "/api",
+		"tags": []interface{}{"team-a"},
+	})
+
+	expectNoError(t, resourceKongServiceCreate(context.Background(), d, kong.client()))
+
+	body := kong.lastRequestTo(http.MethodPost, "services").Body
+	if body["name"] != "example" || body["host"] != "example.com" || body["path"] != "/api" || body["retries"] != float64(5) {
+		t.Errorf("unexpected body sent: %v", body)
+	}
+	if tags, _ := body["tags"].([]interface{}); len(tags) != 1 || tags[0] != "team-a" {
+		t.Errorf("unexpected tags sent: %v", body["tags"])
+	}
+
+	if d.Id() == "" || kong.get("services", d.Id()) == nil {
+		t.Errorf("service %q not stored in the state", d.Id())
+	}
+}
+
+func TestResourceKongServiceCreateConflict(t *testing.T) {
+	kong := newFakeKong(t)
+	kong.put("services", map[string]interface{}{"name": "example", "host": "example.com"})
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"name": "example",
+		"host": "example.com",
+	})
+
+	err := diagnosticsError(t, resourceKongServiceCreate(context.Background(), d, kong.client()))
+	if !strings.Contains(err, "409 Conflict") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestResourceKongServiceReadDrift(t *testing.T) {
+	kong := newFakeKong(t)
+	id := kong.put("services", map[string]interface{}{
+		"name":    "example",
+		"host":    "changed.example.com",
+		"retries": 3,
+		"tags":    []interface{}{"changed"},
+	})
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"name": "example",
+		"host": "example.com",
+	})
+	d.SetId(id)
+
+	expectNoError(t, resourceKongServiceRead(context.Background(), d, kong.client()))
+
+	if host := d.Get("host").(string); host != "changed.example.com" {
+		t.Errorf("host is %q, want the value changed in Kong", host)
+	}
+	if retries := d.Get("retries").(int); retries != 3 {
+		t.Errorf("retries is %d, want the value changed in Kong", retries)
+	}
+	if tags := d.Get("tags").(*schema.Set); tags.Len() != 1 || !tags.Contains("changed") {
+		t.Errorf("tags are %v, want the values changed in Kong", tags.List())
+	}
+}
+
+func TestResourceKongServiceReadNotFound(t *testing.T) {
+	kong := newFakeKong(t)
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"host": "example.com",
+	})
+	d.SetId("00000000-0000-4000-8000-000000000042")
+
+	expectNoError(t, resourceKongServiceRead(context.Background(), d, kong.client()))
+
+	if d.Id() != "" {
+		t.Errorf("ID is %q, want it cleared for a service deleted outside of Terraform", d.Id())
+	}
+}
+
+func TestResourceKongServiceUpdateUnexpectedStatus(t *testing.T) {
+	kong := newFakeKong(t)
+	id := kong.put("services", map[string]interface{}{"name": "example", "host": "example.com"})
+	kong.respond(http.MethodPatch, "services/"+id, http.StatusBadRequest, map[string]interface{}{
+		"message": "schema violation (host: invalid value)",
+	})
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"name": "example",
+		"host": "example.com",
+	})
+	d.SetId(id)
+
+	err := diagnosticsError(t, resourceKongServiceUpdate(context.Background(), d, kong.client()))
+	if !strings.Contains(err, "400 Bad Request: schema violation (host: invalid value)") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestResourceKongServiceDeleteNotFound(t *testing.T) {
+	kong := newFakeKong(t)
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"host": "example.com",
+	})
+	d.SetId("00000000-0000-4000-8000-000000000042")
+
+	expectNoError(t, resourceKongServiceDelete(context.Background(), d, kong.client()))
+}
+
+func TestResourceKongServiceDeleteWithRoutes(t *testing.T) {
+	kong := newFakeKong(t)
+	id := kong.put("services", map[string]interface{}{"name": "example", "host": "example.com"})
+	kong.put("routes", map[string]interface{}{"name": "example-route", "service": map[string]interface{}{"id": id}})
+	d := schema.TestResourceDataRaw(t, resourceKongService().Schema, map[string]interface{}{
+		"host": "example.com",
+	})
+	d.SetId(id)
+
+	err := diagnosticsError(t, resourceKongServiceDelete(context.Background(), d, kong.client()))
+	if !strings.Contains(err, "still has 1 Routes: example-route") {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if kong.get("services", id) == nil {
+		t.Error("service deleted despite its routes")
+	}
+}
+
+func TestResourceKongServicePlanDefaults(t *testing.T) {
+	r := resourceKongService()
+
+	for _, config := range []map[string]interface{}{
+		{"name": "example", "host": "example.com"},
+		{"name": "example", "url": "http://example.com"},
+	} {
+		diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := map[string]string{"protocol": "http", "host": "example.com", "port": "80"}
+		for k, v := range want {
+			if a := diff.Attributes[k]; a == nil || a.NewComputed || a.New != v {
+				t.Errorf("%v: %s planned as %+v, want %q", config, k, a, v)
+			}
+		}
+
+		// Kong leaves path null when it isn't set.
+		if a := diff.Attributes["path"]; a != nil && a.New != "" {
+			t.Errorf("%v: path planned as %q, want it left unset", config, a.New)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,73 +15,99 @@ type SNI struct {
 	Name             string      `json:"name,omitempty"`
 	SSLCertificateID Certificate `json:"certificate,omitempty"`
 	Tags             []string    `json:"tags"`
+	UpdatedAt        int         `json:"updated_at,omitempty"`
 }
 
 func resourceKongSNI() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongSNICreate,
-		Read:   resourceKongSNIRead,
-		Update: resourceKongSNIUpdate,
-		Delete: resourceKongSNIDelete,
+		CreateContext: resourceKongSNICreate,
+		ReadContext:   resourceKongSNIRead,
+		UpdateContext: resourceKongSNIUpdate,
+		DeleteContext: resourceKongSNIDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceEntity("sni"),
+		},
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		CustomizeDiff: customdiff.All(
+			computeUpdatedAtOnChange,
+			validateReferences(entityReference{attribute: "certificate", collection: "certificates", entity: "certificate"}),
+		),
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the SNI belongs to. Defaults to the default workspace.",
+			},
+
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The SNI name to associate with the given sni.",
 			},
 			"certificate": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The id (a UUID) of the certificate with which to associate the SNI hostname.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUIDReference,
+				Description:  "The id (a UUID) of the certificate with which to associate the SNI hostname.",
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
+
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the SNI was last updated.",
+			},
 		},
 	}
 }
 
-func resourceKongSNICreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongSNICreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	sni := getSNIFromResourceData(d)
 
 	createdSNI := getSNIFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(sni).Post("snis/").ReceiveSuccess(createdSNI)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(sni).Post("snis/").ReceiveSuccess(createdSNI)
 	if error != nil {
-		return fmt.Errorf("error while creating SNI")
+		return diag.Errorf("error while creating SNI: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setSNIToResourceData(d, createdSNI)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongSNIRead)
 }
 
-func resourceKongSNIRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongSNIRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	sni := getSNIFromResourceData(d)
 
-	response, error := sling.New().Path("snis/").Get(sni.Name).ReceiveSuccess(sni)
+	response, error := sling.readEntity(ctx, d.Get("workspace").(string), "snis/"+pathSegment(d.Id()), "snis", d.Id(), sni)
 	if error != nil {
-		return fmt.Errorf("error while updating SNI")
+		return diag.Errorf("error while reading SNI: %s", error)
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setSNIToResourceData(d, sni)
@@ -87,42 +115,52 @@ func resourceKongSNIRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongSNIUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongSNIUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "SNI", "snis/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	sni := getSNIFromResourceData(d)
 
 	updatedSNI := getSNIFromResourceData(d)
 
-	response, error := sling.New().BodyJSON(sni).Path("snis/").Patch(sni.Name).ReceiveSuccess(updatedSNI)
+	response, error := sling.Workspace(d.Get("workspace").(string)).BodyJSON(sni).Path("snis/").Patch(pathSegment(sni.Name)).ReceiveSuccess(updatedSNI)
 	if error != nil {
-		return fmt.Errorf("error while updating SNI")
+		return diag.Errorf("error while updating SNI: %s", error)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setSNIToResourceData(d, updatedSNI)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongSNIRead)...)
 }
 
-func resourceKongSNIDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongSNIDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, sling, "SNI", "snis/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	sni := getSNIFromResourceData(d)
 
-	response, error := sling.New().Path("snis/").Delete(sni.Name).ReceiveSuccess(nil)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("snis/").Delete(pathSegment(sni.Name)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting SNI")
+		return diag.Errorf("error while deleting SNI: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getSNIFromResourceData(d *schema.ResourceData) *SNI {
@@ -131,7 +169,7 @@ func getSNIFromResourceData(d *schema.ResourceData) *SNI {
 		SSLCertificateID: Certificate{
 			ID: d.Get("certificate").(string),
 		},
-		Tags: helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags: helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return sni
@@ -140,6 +178,7 @@ func getSNIFromResourceData(d *schema.ResourceData) *SNI {
 func setSNIToResourceData(d *schema.ResourceData, sni *SNI) {
 	d.SetId(sni.Name)
 	d.Set("name", sni.Name)
-	d.Set("certificate", sni.SSLCertificateID)
+	d.Set("certificate", sni.SSLCertificateID.ID)
 	d.Set("tags", sni.Tags)
+	d.Set("updated_at", sni.UpdatedAt)
 }
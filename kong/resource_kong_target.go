@@ -1,30 +1,51 @@
 package kong
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
 	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type Target struct {
 	ID       string   `json:"id,omitempty"`
 	Upstream string   `json:"-"`
 	Target   string   `json:"target,omitempty"`
-	Weight   int      `json:"weight,omitempty"`
+	Weight   int      `json:"weight"`
 	Tags     []string `json:"tags"`
+
+	// UpstreamReference is the upstream as returned by Kong, the upstream being sent in the path instead.
+	UpstreamReference *pluginReference `json:"upstream,omitempty"`
 }
 
 func resourceKongTarget() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongTargetCreate,
-		Read:   resourceKongTargetRead,
-		Delete: resourceKongTargetDelete,
+		CreateContext: resourceKongTargetCreate,
+		ReadContext:   resourceKongTargetRead,
+		UpdateContext: resourceKongTargetUpdate,
+		DeleteContext: resourceKongTargetDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importTarget,
+		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the target belongs to. Defaults to the default workspace.",
+			},
+
 			"upstream": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -43,13 +64,15 @@ func resourceKongTarget() *schema.Resource {
 			},
 
 			"weight": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "The weight this target gets within the upstream load balancer, 0 taking it out of rotation. Changing it updates the target in place.",
 			},
 
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				ForceNew:    true,
@@ -59,56 +82,165 @@ func resourceKongTarget() *schema.Resource {
 	}
 }
 
-func resourceKongTargetCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongTargetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	target := getTargetFromResourceData(d)
 
 	createdTarget := getTargetFromResourceData(d)
 
-	response, error := sling.New().Path("upstreams/").Path(target.Upstream + "/").BodyJSON(target).Post("targets/").ReceiveSuccess(createdTarget)
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("upstreams/").Path(pathSegment(target.Upstream) + "/").BodyJSON(target).Post("targets/").ReceiveSuccess(createdTarget)
 	if error != nil {
-		return fmt.Errorf("error while creating target")
+		return diag.Errorf("error while creating target: %s", error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setTargetToResourceData(d, createdTarget)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongTargetRead)
 }
 
-func resourceKongTargetRead(d *schema.ResourceData, meta interface{}) error {
-	// Targets can't be read, so we ignore the read operation.
+// resourceKongTargetRead reads the target from its upstream. Kong nodes older than 2.2 can't read a single target, on
+// which the state is kept as it is.
+func resourceKongTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	targets := "upstreams/" + pathSegment(d.Get("upstream").(string)) + "/targets"
+	target := new(Target)
+
+	response, err := sling.readEntity(ctx, d.Get("workspace").(string), targets+"/"+pathSegment(d.Id()), targets, d.Id(), target)
+	if err != nil {
+		return diag.Errorf("error while reading target: %s", err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		d.SetId("")
+		return nil
+	case http.StatusMethodNotAllowed:
+		return nil
+	default:
+		return diag.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	_ = d.Set("target", target.Target)
+	_ = d.Set("weight", target.Weight)
+	_ = d.Set("tags", target.Tags)
+
 	return nil
 }
 
-func resourceKongTargetDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+// importTarget accepts "<upstream>/<target_id>" or "<workspace>/<upstream>/<target_id>", the upstream being given by ID
+// or name.
+func importTarget(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	switch len(parts) {
+	case 2:
+	case 3:
+		_ = d.Set("workspace", parts[0])
+		parts = parts[1:]
+	default:
+		return nil, fmt.Errorf("expected a string in the format \"<upstream>/<target_id>\" or \"<workspace>/<upstream>/<target_id>\" to import")
+	}
+
+	_ = d.Set("upstream", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceKongTargetUpdate changes the weight without deleting the target first, so that the backend stays in rotation
+// while its weight changes.
+func resourceKongTargetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
 
 	target := getTargetFromResourceData(d)
 
-	response, error := sling.New().Path("upstreams/").Path(target.Upstream + "/").Path("targets/").Delete(target.ID).ReceiveSuccess(nil)
+	updatedTarget, err := setTargetWeight(sling.Workspace(d.Get("workspace").(string)), target.Upstream, target.ID, target.Target, target.Weight)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Kong versions without in place updates give the new entry of the target a new id.
+	if updatedTarget.ID != "" {
+		d.SetId(updatedTarget.ID)
+	}
+
+	return readAfterWrite(ctx, d, meta, resourceKongTargetRead)
+}
+
+func resourceKongTargetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sling := meta.(*Client).WithContext(ctx)
+
+	target := getTargetFromResourceData(d)
+
+	response, error := sling.Workspace(d.Get("workspace").(string)).Path("upstreams/").Path(pathSegment(target.Upstream) + "/").Path("targets/").Delete(pathSegment(target.ID)).ReceiveSuccess(nil)
 	if error != nil {
-		return fmt.Errorf("error while deleting target")
+		return diag.Errorf("error while deleting target: %s", error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
 	return nil
 }
 
+// setTargetWeight changes the weight of a target in place, which Kong supports from 2.2. Older nodes have no update
+// endpoint for targets and answer 405, on which the target is posted again instead, its newest entry replacing the
+// previous ones. The target is looked up by id when known, and by address otherwise.
+func setTargetWeight(request *sling.Sling, upstream string, id string, target string, weight int) (*Target, error) {
+	updatedTarget := new(Target)
+
+	key := id
+	if key == "" {
+		key = target
+	}
+
+	// The weight is always sent, as 0 takes the target out of rotation.
+	body := &struct {
+		Target string `json:"target"`
+		Weight int    `json:"weight"`
+	}{Target: target, Weight: weight}
+	response, err := request.New().Path("upstreams/").Path(pathSegment(upstream) + "/").Path("targets/").BodyJSON(body).Patch(pathSegment(key)).ReceiveSuccess(updatedTarget)
+	if err != nil {
+		return nil, fmt.Errorf("error while updating target %s: %s", target, err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return updatedTarget, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("target %s not found in upstream %s, it was deleted outside of Terraform: refresh to plan its creation", target, upstream)
+	case http.StatusMethodNotAllowed:
+	default:
+		return nil, fmt.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	response, err = request.New().Path("upstreams/").Path(pathSegment(upstream) + "/").BodyJSON(body).Post("targets/").ReceiveSuccess(updatedTarget)
+	if err != nil {
+		return nil, fmt.Errorf("error while updating target %s: %s", target, err)
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code received: %s", response.Status)
+	}
+
+	return updatedTarget, nil
+}
+
 func getTargetFromResourceData(d *schema.ResourceData) *Target {
 	target := &Target{
 		ID:       d.Id(),
 		Target:   d.Get("target").(string),
 		Upstream: d.Get("upstream").(string),
 		Weight:   d.Get("weight").(int),
-		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:     helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 	}
 
 	return target
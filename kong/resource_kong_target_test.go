@@ -0,0 +1,131 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceKongTargetImport(t *testing.T) {
+	tests := []struct {
+		id        string
+		workspace string
+		upstream  string
+		target    string
+		fails     bool
+	}{
+		{id: "example/00000000-0000-4000-8000-000000000001", upstream: "example", target: "00000000-0000-4000-8000-000000000001"},
+		{id: "team/example/00000000-0000-4000-8000-000000000001", workspace: "team", upstream: "example", target: "00000000-0000-4000-8000-000000000001"},
+		{id: "00000000-0000-4000-8000-000000000001", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceKongTarget().Schema, map[string]interface{}{})
+			d.SetId(tt.id)
+
+			_, err := importTarget(context.Background(), d, nil)
+			if tt.fails {
+				if err == nil {
+					t.Error("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d.Get("workspace") != tt.workspace || d.Get("upstream") != tt.upstream || d.Id() != tt.target {
+				t.Errorf("imported workspace %q, upstream %q and ID %q", d.Get("workspace"), d.Get("upstream"), d.Id())
+			}
+		})
+	}
+}
+
+func TestResourceKongTargetRead(t *testing.T) {
+	kong := newFakeKong(t)
+	id := kong.put("targets", map[string]interface{}{
+		"target":   "10.0.0.10:8000",
+		"weight":   0,
+		"tags":     []interface{}{"drained"},
+		"upstream": map[string]interface{}{"id": "00000000-0000-4000-8000-0000000000a1"},
+	})
+	d := schema.TestResourceDataRaw(t, resourceKongTarget().Schema, map[string]interface{}{
+		"upstream": "example",
+		"target":   "10.0.0.10:8000",
+		"weight":   100,
+	})
+	d.SetId(id)
+
+	expectNoError(t, resourceKongTargetRead(context.Background(), d, kong.client()))
+
+	kong.lastRequestTo(http.MethodGet, "upstreams/example/targets/"+id)
+	if weight := d.Get("weight").(int); weight != 0 {
+		t.Errorf("weight is %d, want the weight changed in Kong", weight)
+	}
+	if tags := d.Get("tags").(*schema.Set); !tags.Contains("drained") {
+		t.Errorf("tags are %v, want the tags changed in Kong", tags.List())
+	}
+
+	d.SetId("00000000-0000-4000-8000-000000000042")
+	expectNoError(t, resourceKongTargetRead(context.Background(), d, kong.client()))
+	if d.Id() != "" {
+		t.Errorf("ID is %q, want it cleared for a target deleted outside of Terraform", d.Id())
+	}
+}
+
+func TestResourceKongTargetUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+		noPatch  bool
+		fails    bool
+	}{
+		{name: "in place", existing: true},
+		{name: "posted again without in place updates", existing: true, noPatch: true},
+		{name: "deleted outside of Terraform", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kong := newFakeKong(t)
+			id := "00000000-0000-4000-8000-000000000042"
+			if tt.existing {
+				id = kong.put("targets", map[string]interface{}{"target": "10.0.0.10:8000", "weight": 100})
+			}
+			if tt.noPatch {
+				kong.respond(http.MethodPatch, "upstreams/example/targets/"+id, http.StatusMethodNotAllowed, nil)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceKongTarget().Schema, map[string]interface{}{
+				"upstream": "example",
+				"target":   "10.0.0.10:8000",
+				"weight":   0,
+			})
+			d.SetId(id)
+
+			diags := resourceKongTargetUpdate(context.Background(), d, kong.client())
+			if tt.fails {
+				if err := diagnosticsError(t, diags); !strings.Contains(err, "deleted outside of Terraform") {
+					t.Errorf("unexpected error: %s", err)
+				}
+				if posted := kong.requestsTo(http.MethodPost, "upstreams/example/targets"); len(posted) > 0 {
+					t.Error("target deleted outside of Terraform re-created by the update")
+				}
+				return
+			}
+			expectNoError(t, diags)
+
+			kong.lastRequestTo(http.MethodGet, "upstreams/example/targets/"+d.Id())
+			if posted := kong.requestsTo(http.MethodPost, "upstreams/example/targets"); (len(posted) > 0) != tt.noPatch {
+				t.Errorf("%d POST requests sent", len(posted))
+			}
+			if weight := d.Get("weight").(int); weight != 0 {
+				t.Errorf("weight is %d after the update, want 0", weight)
+			}
+		})
+	}
+}
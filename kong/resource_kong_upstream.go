@@ -1,12 +1,13 @@
 package kong
 
 import (
-	"fmt"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -80,40 +81,59 @@ type Upstream struct {
 	HealthChecks            *UpstreamHealthChecks `json:"healthchecks,omitempty"`
 	Tags                    []string              `json:"tags"`
 	HostHeader              string                `json:"host_header,omitempty"`
-	ClientCertificate       Certificate           `json:"-"`
+	ClientCertificate       *pluginReference      `json:"client_certificate"`
 	UseSrvName              bool                  `json:"use_srv_name"`
+	UpdatedAt               int                   `json:"updated_at,omitempty"`
 }
 
 func resourceKongUpstream() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongUpstreamCreate,
-		Read:   resourceKongUpstreamRead,
-		Update: resourceKongUpstreamUpdate,
-		Delete: resourceKongUpstreamDelete,
+		CreateContext: resourceKongUpstreamCreate,
+		ReadContext:   resourceKongUpstreamRead,
+		UpdateContext: resourceKongUpstreamUpdate,
+		DeleteContext: resourceKongUpstreamDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importNamedEntity("upstream", "upstreams", "name"),
+		},
+
+		CustomizeDiff: computeUpdatedAtOnChange,
+
+		Timeouts: resourceTimeouts(20 * time.Minute),
 
 		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The Kong Enterprise workspace the upstream belongs to. Defaults to the default workspace.",
+			},
+
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "This is a hostname, which must be equal to the host of a Service.",
 			},
 			"algorithm": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Which load balancing algorithm to use. One of: round-robin, consistent-hashing, or least-connections. Defaults to \"round-robin\". Kong 1.3.0 and up.",
-				Default:     "round-robin",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateOneOf(upstreamAlgorithms...),
+				Description:      "Which load balancing algorithm to use. One of: round-robin, consistent-hashing, least-connections or latency. Defaults to \"round-robin\". Kong 1.3.0 and up.",
+				Default:          "round-robin",
 			},
 			"hash_on": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "What to use as hashing input: none, consumer, ip, header, or cookie (defaults to none resulting in a weighted-round-robin scheme).",
-				Default:     "none",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateOneOf(upstreamHashInputs...),
+				Description:      "What to use as hashing input: none, consumer, ip, header, cookie, path, query_arg or uri_capture (defaults to none resulting in a weighted-round-robin scheme).",
+				Default:          "none",
 			},
 			"hash_fallback": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "What to use as hashing input if the primary hash_on does not return a hash (eg. header is missing, or no consumer identified). One of: none, consumer, ip, header, or cookie (defaults to none, not available if hash_on is set to cookie).",
-				Default:     "none",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateOneOf(upstreamHashInputs...),
+				Description:      "What to use as hashing input if the primary hash_on does not return a hash (eg. header is missing, or no consumer identified). One of: none, consumer, ip, header, cookie, path, query_arg or uri_capture (defaults to none, not available if hash_on is set to cookie).",
+				Default:          "none",
 			},
 			"hash_on_header": {
 				Type:        schema.TypeString,
@@ -182,9 +202,10 @@ func resourceKongUpstream() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "http",
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          "http",
+										ValidateDiagFunc: validateOneOf(healthcheckTypes...),
 									},
 									"timeout": {
 										Type:     schema.TypeInt,
@@ -224,7 +245,7 @@ func resourceKongUpstream() *schema.Resource {
 													Computed: true,
 												},
 												"http_statuses": {
-													Type:     schema.TypeList,
+													Type:     schema.TypeSet,
 													Optional: true,
 													Computed: true,
 													Elem: &schema.Schema{
@@ -252,7 +273,7 @@ func resourceKongUpstream() *schema.Resource {
 													Computed: true,
 												},
 												"http_statuses": {
-													Type:     schema.TypeList,
+													Type:     schema.TypeSet,
 													Optional: true,
 													Computed: true,
 													Elem: &schema.Schema{
@@ -288,9 +309,10 @@ func resourceKongUpstream() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "http",
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          "http",
+										ValidateDiagFunc: validateOneOf(healthcheckTypes...),
 									},
 									"healthy": {
 										Type:     schema.TypeList,
@@ -300,7 +322,7 @@ func resourceKongUpstream() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"http_statuses": {
-													Type:     schema.TypeList,
+													Type:     schema.TypeSet,
 													Optional: true,
 													Elem: &schema.Schema{
 														Type: schema.TypeInt,
@@ -321,7 +343,7 @@ func resourceKongUpstream() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"http_statuses": {
-													Type:     schema.TypeList,
+													Type:     schema.TypeSet,
 													Optional: true,
 													Elem: &schema.Schema{
 														Type: schema.TypeInt,
@@ -349,11 +371,16 @@ func resourceKongUpstream() *schema.Resource {
 				},
 			},
 			"tags": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
+			"updated_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix epoch when the upstream was last updated.",
+			},
 			"client_certificate": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -362,6 +389,12 @@ func resourceKongUpstream() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the targets of the upstream before deleting it, instead of relying on Kong to delete them along with the upstream.",
+			},
 			"host_header": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -371,42 +404,42 @@ func resourceKongUpstream() *schema.Resource {
 	}
 }
 
-func resourceKongUpstreamCreate(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
+func resourceKongUpstreamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	Sling := meta.(*Client).WithContext(ctx)
 
 	upstream := getUpstreamFromResourceData(d)
 
 	createdUpstream := getUpstreamFromResourceData(d)
 
-	response, Error := Sling.New().BodyJSON(upstream).Post("upstreams/").ReceiveSuccess(createdUpstream)
+	response, Error := Sling.Workspace(d.Get("workspace").(string)).BodyJSON(upstream).Post("upstreams/").ReceiveSuccess(createdUpstream)
 	if Error != nil {
-		return fmt.Errorf("error while creating upstream")
+		return diag.Errorf("error while creating upstream: %s", Error)
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setUpstreamToResourceData(d, createdUpstream)
 
-	return nil
+	return readAfterWrite(ctx, d, meta, resourceKongUpstreamRead)
 }
 
-func resourceKongUpstreamRead(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
+func resourceKongUpstreamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	Sling := meta.(*Client).WithContext(ctx)
 
 	upstream := getUpstreamFromResourceData(d)
 
-	response, Error := Sling.New().Path("upstreams/").Get(upstream.ID).ReceiveSuccess(upstream)
+	response, Error := Sling.readEntity(ctx, d.Get("workspace").(string), "upstreams/"+pathSegment(upstream.ID), "upstreams", upstream.ID, upstream)
 	if Error != nil {
-		return fmt.Errorf(Error.Error()) //fmt.Errorf("Error while updating upstream")
+		return diag.Errorf(Error.Error()) //fmt.Errorf("Error while updating upstream")
 	}
 
 	if response.StatusCode == http.StatusNotFound {
 		d.SetId("")
 		return nil
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setUpstreamToResourceData(d, upstream)
@@ -414,41 +447,57 @@ func resourceKongUpstreamRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongUpstreamUpdate(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
+func resourceKongUpstreamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	Sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, Sling, "upstream", "upstreams/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	upstream := getUpstreamFromResourceData(d)
 	updatedUpstream := getUpstreamFromResourceData(d)
 
-	response, Error := Sling.New().BodyJSON(upstream).Path("upstreams/").Patch(upstream.ID).ReceiveSuccess(updatedUpstream)
+	response, Error := Sling.Workspace(d.Get("workspace").(string)).BodyJSON(upstream).Path("upstreams/").Patch(pathSegment(upstream.ID)).ReceiveSuccess(updatedUpstream)
 	if Error != nil {
-		return fmt.Errorf(Error.Error()) //fmt.Errorf("Error while updating upstream")
+		return diag.Errorf(Error.Error()) //fmt.Errorf("Error while updating upstream")
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+		return diag.Errorf("%s", response.Status)
 	}
 
 	setUpstreamToResourceData(d, updatedUpstream)
 
-	return nil
+	return append(diags, readAfterWrite(ctx, d, meta, resourceKongUpstreamRead)...)
 }
 
-func resourceKongUpstreamDelete(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
+func resourceKongUpstreamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	Sling := meta.(*Client).WithContext(ctx)
+
+	diags := checkConcurrentChanges(d, Sling, "upstream", "upstreams/"+pathSegment(d.Id()))
+	if diags.HasError() {
+		return diags
+	}
 
 	upstream := getUpstreamFromResourceData(d)
 
-	response, Error := Sling.New().Path("upstreams/").Delete(upstream.ID).ReceiveSuccess(nil)
+	if d.Get("force_destroy").(bool) {
+		if err := deleteDependents(Sling.Workspace(d.Get("workspace").(string)), Sling.pageSize, "upstreams/"+pathSegment(upstream.ID)+"/targets"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	response, Error := Sling.Workspace(d.Get("workspace").(string)).Path("upstreams/").Delete(pathSegment(upstream.ID)).ReceiveSuccess(nil)
 	if Error != nil {
-		return fmt.Errorf("error while deleting upstream")
+		return diag.Errorf("error while deleting upstream: %s", Error)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+		return diag.Errorf("%s", response.Status)
 	}
 
-	return nil
+	return diags
 }
 
 func getActiveHealthyFromMap(d *map[string]interface{}) *ActiveHealthy {
@@ -654,12 +703,13 @@ func getUpstreamFromResourceData(d *schema.ResourceData) *Upstream {
 		HashOnUriCapture:        d.Get("hash_on_uri_capture").(string),
 		HashFallbacOnUriCapture: d.Get("hash_fallback_uri_capture").(string),
 		Slots:                   d.Get("slots").(int),
-		Tags:                    helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Tags:                    helper.ConvertInterfaceArrToStrings(d.Get("tags").(*schema.Set).List()),
 		HostHeader:              d.Get("host_header").(string),
-		ClientCertificate: Certificate{
-			ID: d.Get("client_certificate").(string),
-		},
-		UseSrvName: d.Get("use_srv_name").(bool),
+		UseSrvName:              d.Get("use_srv_name").(bool),
+	}
+
+	if id := d.Get("client_certificate").(string); id != "" {
+		upstream.ClientCertificate = &pluginReference{ID: id}
 	}
 
 	hcArr := d.Get("healthchecks").([]interface{})
@@ -796,6 +846,7 @@ func setUpstreamToResourceData(d *schema.ResourceData, upstream *Upstream) {
 	d.Set("hash_on_header", upstream.HashOnHeader)
 	d.Set("hash_fallback_header", upstream.HashFallbackHeader)
 	d.Set("hash_on_cookie", upstream.HashOnCookie)
+	d.Set("hash_on_cookie_path", upstream.HashOnCookiePath)
 	d.Set("hash_on_query_arg", upstream.HashOnQueryArg)
 	d.Set("hash_fallback_query_arg", upstream.HashFallbackOnQueryArg)
 	d.Set("hash_on_uri_capture", upstream.HashOnUriCapture)
@@ -803,12 +854,21 @@ func setUpstreamToResourceData(d *schema.ResourceData, upstream *Upstream) {
 	d.Set("slots", upstream.Slots)
 	d.Set("healthchecks", convertHealthCheckResourceData(upstream.HealthChecks))
 	d.Set("tags", upstream.Tags)
+	d.Set("updated_at", upstream.UpdatedAt)
 	d.Set("host_header", upstream.HostHeader)
-	d.Set("client_certificate", upstream.ClientCertificate)
+	if upstream.ClientCertificate != nil {
+		d.Set("client_certificate", upstream.ClientCertificate.ID)
+	} else {
+		d.Set("client_certificate", "")
+	}
 	d.Set("use_srv_name", upstream.UseSrvName)
 }
 
 func readIntArrayFromInterface(in interface{}) []int {
+	if set, ok := in.(*schema.Set); ok {
+		in = set.List()
+	}
+
 	if arr := in.([]interface{}); arr != nil {
 		array := make([]int, len(arr))
 		for i, x := range arr {
@@ -1,12 +1,18 @@
 package kong
 
 import (
+	"context"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"address": {
 				Type:     schema.TypeString,
@@ -22,34 +28,205 @@ func Provider() *schema.Provider {
 				Optional: true,
 				Default:  "",
 			},
+			"validate_references": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to check at plan time that the entities referenced by the resources, e.g. the service of a route, exist in Kong.",
+			},
+			"fail_on_concurrent_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether updates and deletes fail, instead of only warning, when the entity was modified outside of Terraform since the last refresh.",
+			},
+			"fail_on_missing_entities": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether refreshing a resource whose entity was deleted outside of Terraform fails, instead of removing it from the state so that the next apply recreates it.",
+			},
+			"strict": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether refreshing an entity fails when Kong returns fields the provider doesn't manage, e.g. added by a Kong upgrade, instead of ignoring them.",
+			},
+			"max_idle_connections_per_host": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      16,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of idle connections to the Admin API kept open for reuse. Raise it along with terraform -parallelism.",
+			},
+			"idle_connection_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "90s",
+				ValidateFunc: validateDuration,
+				Description:  "How long an idle connection to the Admin API is kept open for reuse, e.g. 90s or 5m.",
+			},
+			"http2": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to use HTTP/2 when the Admin API supports it over TLS. Set it to false for proxies in front of the Admin API mishandling HTTP/2.",
+			},
+			"bulk_refresh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to refresh the entities of each collection from a single listing, instead of reading them one by one, which speeds up the refresh of states with thousands of consumers or credentials.",
+			},
+			"bulk_refresh_tags": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Restricts the listings of bulk_refresh to the entities having all of these tags, e.g. the tags of the entities managed by this configuration. Other entities are read one by one.",
+			},
+			"wait_for_data_planes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether creates, updates and deletes wait for every data plane connected to the hybrid control plane to report a new configuration hash in /clustering/data-planes, so that a successful apply means the data planes serve the change. Changes are then applied one at a time.",
+			},
+			"data_planes_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "5m",
+				ValidateFunc: validateDuration,
+				Description:  "How long to wait for the data planes with wait_for_data_planes, e.g. 30s or 5m.",
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      maxPageSize,
+				ValidateFunc: validation.IntBetween(1, maxPageSize),
+				Description:  "The number of entities requested per page when listing collections, up to 1000. Smaller pages make more requests with smaller responses.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"kong_service":                        resourceKongService(),
-			"kong_route":                          resourceKongRoute(),
-			"kong_consumer":                       resourceKongConsumer(),
-			"kong_plugin":                         resourceKongPlugin(),
-			"kong_consumer_basic_auth_credential": resourceKongBasicAuthCredential(),
-			"kong_consumer_key_auth_credential":   resourceKongKeyAuthCredential(),
-			"kong_consumer_jwt_credential":        resourceKongJWTCredential(),
-			"kong_consumer_acl_group":             resourceKongConsumerACLGroup(),
-			"kong_certificate":                    resourceKongCertificate(),
-			"kong_ca_certificate":                 resourceKongCACertificate(),
-			"kong_sni":                            resourceKongSNI(),
-			"kong_upstream":                       resourceKongUpstream(),
-			"kong_target":                         resourceKongTarget(),
+			"kong_service":                             resourceKongService(),
+			"kong_route":                               resourceKongRoute(),
+			"kong_consumer":                            resourceKongConsumer(),
+			"kong_plugin":                              resourceKongPlugin(),
+			"kong_consumer_basic_auth_credential":      resourceKongBasicAuthCredential(),
+			"kong_consumer_key_auth_credential":        resourceKongKeyAuthCredential(),
+			"kong_consumer_jwt_credential":             resourceKongJWTCredential(),
+			"kong_consumer_acl_group":                  resourceKongConsumerACLGroup(),
+			"kong_certificate":                         resourceKongCertificate(),
+			"kong_ca_certificate":                      resourceKongCACertificate(),
+			"kong_sni":                                 resourceKongSNI(),
+			"kong_upstream":                            resourceKongUpstream(),
+			"kong_target":                              resourceKongTarget(),
+			"kong_canary_release":                      resourceKongCanaryRelease(),
+			"kong_plugin_rate_limiting_advanced":       resourceKongPluginRateLimitingAdvanced(),
+			"kong_plugin_key_auth":                     resourceKongPluginKeyAuth(),
+			"kong_plugin_jwt":                          resourceKongPluginJWT(),
+			"kong_plugin_hmac_auth":                    resourceKongPluginHMACAuth(),
+			"kong_plugin_ldap_auth":                    resourceKongPluginLDAPAuth(),
+			"kong_plugin_ldap_auth_advanced":           resourceKongPluginLDAPAuthAdvanced(),
+			"kong_plugin_mtls_auth":                    resourceKongPluginMTLSAuth(),
+			"kong_plugin_acl":                          resourceKongPluginACL(),
+			"kong_plugin_cors":                         resourceKongPluginCORS(),
+			"kong_plugin_ip_restriction":               resourceKongPluginIPRestriction(),
+			"kong_plugin_bot_detection":                resourceKongPluginBotDetection(),
+			"kong_plugin_request_termination":          resourceKongPluginRequestTermination(),
+			"kong_plugin_request_size_limiting":        resourceKongPluginRequestSizeLimiting(),
+			"kong_plugin_request_transformer":          resourceKongPluginRequestTransformer(),
+			"kong_plugin_response_transformer":         resourceKongPluginResponseTransformer(),
+			"kong_plugin_request_transformer_advanced": resourceKongPluginRequestTransformerAdvanced(),
+			"kong_plugin_correlation_id":               resourceKongPluginCorrelationID(),
+			"kong_plugin_grpc_web":                     resourceKongPluginGRPCWeb(),
+			"kong_plugin_proxy_cache_advanced":         resourceKongPluginProxyCacheAdvanced(),
+			"kong_plugin_prometheus":                   resourceKongPluginPrometheus(),
+			"kong_plugin_opentelemetry":                resourceKongPluginOpenTelemetry(),
+			"kong_plugin_statsd":                       resourceKongPluginStatsd(),
+			"kong_plugin_file_log":                     resourceKongPluginFileLog(),
+			"kong_plugin_tcp_log":                      resourceKongPluginTCPLog(),
+			"kong_plugin_udp_log":                      resourceKongPluginUDPLog(),
+			"kong_plugin_syslog":                       resourceKongPluginSyslog(),
+			"kong_plugin_kafka_log":                    resourceKongPluginKafkaLog(),
+			"kong_plugin_kafka_upstream":               resourceKongPluginKafkaUpstream(),
+			"kong_plugin_pre_function":                 resourceKongPluginPreFunction(),
+			"kong_plugin_post_function":                resourceKongPluginPostFunction(),
+			"kong_plugin_opa":                          resourceKongPluginOPA(),
+			"kong_plugin_jwt_signer":                   resourceKongPluginJWTSigner(),
+			"kong_plugin_forward_proxy":                resourceKongPluginForwardProxy(),
+			"kong_plugin_vault_auth":                   resourceKongPluginVaultAuth(),
+			"kong_plugin_acme":                         resourceKongPluginACME(),
+			"kong_plugin_ai_prompt_guard":              resourceKongPluginAIPromptGuard(),
+			"kong_plugin_jq":                           resourceKongPluginJQ(),
+			"kong_plugin_exit_transformer":             resourceKongPluginExitTransformer(),
+			"kong_plugin_response_ratelimiting":        resourceKongPluginResponseRateLimiting(),
+			"kong_plugin_graphql_proxy_cache_advanced": resourceKongPluginGraphQLProxyCacheAdvanced(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
+	}
+
+	// Hand-written typed plugin resources take precedence over the generated ones.
+	for name, resource := range generatedPluginResources {
+		if _, ok := provider.ResourcesMap[name]; !ok {
+			provider.ResourcesMap[name] = resource()
+		}
 	}
+
+	for name, resource := range provider.ResourcesMap {
+		resource.ReadContext = failOnMissingEntity(name, refreshRead(resource.ReadContext))
+		resource.CreateContext = waitForDataPlanes(resource.CreateContext)
+		resource.UpdateContext = waitForDataPlanes(resource.UpdateContext, localAttributes...)
+		resource.DeleteContext = waitForDataPlanes(resource.DeleteContext)
+	}
+
+	// Purging the cache changes no entity, so it has nothing to refresh and no configuration for the data planes to
+	// apply, and is left out of the wrappers above.
+	provider.ResourcesMap["kong_node_cache_purge"] = resourceKongNodeCachePurge()
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
 		Address:  d.Get("address").(string),
 		Username: d.Get("username").(string),
 		Password: d.Get("password").(string),
+
+		ValidateReferences:      d.Get("validate_references").(bool),
+		FailOnConcurrentChanges: d.Get("fail_on_concurrent_changes").(bool),
+		FailOnMissingEntities:   d.Get("fail_on_missing_entities").(bool),
+		Strict:                  d.Get("strict").(bool),
+
+		MaxIdleConnsPerHost: d.Get("max_idle_connections_per_host").(int),
+		DisableHTTP2:        !d.Get("http2").(bool),
+
+		BulkRefresh:     d.Get("bulk_refresh").(bool),
+		BulkRefreshTags: helper.ConvertInterfaceArrToStrings(d.Get("bulk_refresh_tags").(*schema.Set).List()),
+
+		PageSize: d.Get("page_size").(int),
 	}
 
-	return config.Client()
+	config.IdleConnTimeout, _ = time.ParseDuration(d.Get("idle_connection_timeout").(string))
+	if d.Get("wait_for_data_planes").(bool) {
+		config.DataPlanesTimeout, _ = time.ParseDuration(d.Get("data_planes_timeout").(string))
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
+}
+
+// resourceTimeouts returns the default timeouts of a resource, which can be changed with a timeouts block. Resources
+// deleting their dependents with force_destroy are given a longer delete timeout.
+func resourceTimeouts(deleteTimeout time.Duration) *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(5 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(5 * time.Minute),
+		Delete: schema.DefaultTimeout(deleteTimeout),
+	}
 }
@@ -0,0 +1,264 @@
+package kong
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// routeExpressionFieldRegexp : fields of the expressions router, e.g. http.path, net.dst.port or http.headers.x_foo
+var routeExpressionFieldRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z0-9_]+)+$`)
+
+// routeExpressionOperators : comparison operators of the expressions router, besides in, not in and contains
+var routeExpressionOperators = []string{"==", "!=", "^=", "=^", ">=", "<=", "~", ">", "<"}
+
+// validateRouteExpression checks the syntax of an expressions router expression, so that typos are reported at plan
+// time instead of by Kong on apply. Whether fields exist and accept the type of their value is left to Kong.
+func validateRouteExpression(v interface{}, k string) ([]string, []error) {
+	expression := v.(string)
+	if strings.TrimSpace(expression) == "" {
+		return nil, []error{fmt.Errorf("%s must not be empty", k)}
+	}
+
+	tokens, err := tokenizeRouteExpression(expression)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid expression: %s", k, err)}
+	}
+
+	p := &routeExpressionParser{tokens: tokens}
+	if err := p.parseExpression(); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid expression: %s", k, err)}
+	}
+	if p.pos < len(p.tokens) {
+		return nil, []error{fmt.Errorf("%s is not a valid expression: unexpected %q", k, p.tokens[p.pos].text)}
+	}
+
+	return nil, nil
+}
+
+type routeExpressionTokenKind int
+
+const (
+	routeExpressionWord routeExpressionTokenKind = iota
+	routeExpressionString
+	routeExpressionOperator
+	routeExpressionLogical
+	routeExpressionNot
+	routeExpressionOpen
+	routeExpressionClose
+)
+
+type routeExpressionToken struct {
+	kind routeExpressionTokenKind
+	text string
+}
+
+func tokenizeRouteExpression(expression string) ([]routeExpressionToken, error) {
+	var tokens []routeExpressionToken
+
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		rest := expression[i:]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, routeExpressionToken{routeExpressionOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, routeExpressionToken{routeExpressionClose, ")"})
+			i++
+		case strings.HasPrefix(rest, "&&") || strings.HasPrefix(rest, "||"):
+			tokens = append(tokens, routeExpressionToken{routeExpressionLogical, rest[:2]})
+			i += 2
+		case c == '"':
+			end := i + 1
+			for ; end < len(expression) && expression[end] != '"'; end++ {
+				if expression[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expression) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", i)
+			}
+			tokens = append(tokens, routeExpressionToken{routeExpressionString, expression[i : end+1]})
+			i = end + 1
+		case strings.HasPrefix(rest, `r#"`):
+			end := strings.Index(rest[3:], `"#`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated raw string starting at position %d", i)
+			}
+			tokens = append(tokens, routeExpressionToken{routeExpressionString, rest[:end+5]})
+			i += end + 5
+		case c == '!' && !strings.HasPrefix(rest, "!="):
+			tokens = append(tokens, routeExpressionToken{routeExpressionNot, "!"})
+			i++
+		default:
+			if op := routeExpressionOperatorPrefix(rest); op != "" {
+				tokens = append(tokens, routeExpressionToken{routeExpressionOperator, op})
+				i += len(op)
+				continue
+			}
+
+			end := i
+			for end < len(expression) && strings.IndexByte(" \t\n\r()\"!=<>~^&|", expression[end]) < 0 {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, routeExpressionToken{routeExpressionWord, expression[i:end]})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+func routeExpressionOperatorPrefix(s string) string {
+	for _, op := range routeExpressionOperators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+// routeExpressionParser : recursive descent parser of the grammar
+//
+//	expression = term { ( "&&" | "||" ) term }
+//	term       = [ "!" ] "(" expression ")" | predicate
+//	predicate  = lhs operator value
+//	lhs        = field | transformation "(" field ")"
+type routeExpressionParser struct {
+	tokens []routeExpressionToken
+	pos    int
+}
+
+func (p *routeExpressionParser) peek() *routeExpressionToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+
+	return &p.tokens[p.pos]
+}
+
+func (p *routeExpressionParser) next() (routeExpressionToken, error) {
+	t := p.peek()
+	if t == nil {
+		return routeExpressionToken{}, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+
+	return *t, nil
+}
+
+func (p *routeExpressionParser) parseExpression() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+
+	for t := p.peek(); t != nil && t.kind == routeExpressionLogical; t = p.peek() {
+		p.pos++
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *routeExpressionParser) parseTerm() error {
+	t := p.peek()
+	if t != nil && t.kind == routeExpressionNot {
+		p.pos++
+		if t = p.peek(); t == nil || t.kind != routeExpressionOpen {
+			return fmt.Errorf("! must be followed by a parenthesized expression")
+		}
+	}
+
+	if t != nil && t.kind == routeExpressionOpen {
+		p.pos++
+		if err := p.parseExpression(); err != nil {
+			return err
+		}
+		if t, err := p.next(); err != nil || t.kind != routeExpressionClose {
+			return fmt.Errorf("missing closing parenthesis")
+		}
+		return nil
+	}
+
+	return p.parsePredicate()
+}
+
+func (p *routeExpressionParser) parsePredicate() error {
+	lhs, err := p.next()
+	if err != nil {
+		return err
+	}
+	if lhs.kind != routeExpressionWord {
+		return fmt.Errorf("expected a field, got %q", lhs.text)
+	}
+
+	// Transformations such as lower(http.path) wrap the field.
+	if t := p.peek(); t != nil && t.kind == routeExpressionOpen {
+		p.pos++
+		if lhs, err = p.next(); err != nil {
+			return err
+		}
+		if t, err := p.next(); err != nil || t.kind != routeExpressionClose {
+			return fmt.Errorf("missing closing parenthesis after %q", lhs.text)
+		}
+	}
+
+	if !routeExpressionFieldRegexp.MatchString(lhs.text) {
+		return fmt.Errorf("%q is not a valid field", lhs.text)
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return fmt.Errorf("missing operator after %q", lhs.text)
+	}
+	switch {
+	case op.kind == routeExpressionOperator:
+	case op.kind == routeExpressionWord && (op.text == "in" || op.text == "contains"):
+	case op.kind == routeExpressionWord && op.text == "not":
+		if t, err := p.next(); err != nil || t.text != "in" {
+			return fmt.Errorf("not must be followed by in")
+		}
+	default:
+		return fmt.Errorf("expected an operator after %q, got %q", lhs.text, op.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return fmt.Errorf("missing value after %q %s", lhs.text, op.text)
+	}
+
+	return validateRouteExpressionValue(value)
+}
+
+// validateRouteExpressionValue accepts string literals, integers, IP addresses and CIDR ranges.
+func validateRouteExpressionValue(value routeExpressionToken) error {
+	switch value.kind {
+	case routeExpressionString:
+		return nil
+	case routeExpressionWord:
+		if _, err := strconv.ParseInt(value.text, 10, 64); err == nil {
+			return nil
+		}
+		if net.ParseIP(value.text) != nil {
+			return nil
+		}
+		if _, _, err := net.ParseCIDR(value.text); err == nil {
+			return nil
+		}
+		return fmt.Errorf("%q is not a valid value, strings must be quoted", value.text)
+	default:
+		return fmt.Errorf("expected a value, got %q", value.text)
+	}
+}
@@ -0,0 +1,104 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dghubble/sling"
+)
+
+// strictIgnoredFields : metadata Kong returns on entities which Terraform has nothing to control
+var strictIgnoredFields = map[string]bool{"id": true, "created_at": true, "updated_at": true, "ws_id": true}
+
+// strictFielder : entity consuming keys its struct fields don't declare, e.g. references tagged json:"-" which a
+// custom UnmarshalJSON decodes or the path of the entity already gives
+type strictFielder interface {
+	strictFields() []string
+}
+
+// strictDecoder : response decoder failing when Kong returns fields which the decoded entity doesn't model, so that
+// attributes added by a Kong upgrade are noticed instead of silently left uncontrolled
+type strictDecoder struct{}
+
+func (strictDecoder) Decode(response *http.Response, v interface{}) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil
+	}
+
+	known := jsonFields(reflect.TypeOf(v))
+	if f, ok := v.(strictFielder); ok {
+		for _, k := range f.strictFields() {
+			known[k] = true
+		}
+	}
+
+	var unknown []string
+	for k := range fields {
+		if !known[strings.ToLower(k)] && !strictIgnoredFields[k] {
+			unknown = append(unknown, k)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("Kong returned fields the provider doesn't manage: %s. Upgrade the provider, or set strict to false to ignore them",
+			strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// jsonFields returns the lower-cased JSON names of the fields of a struct, matched case-insensitively like
+// encoding/json does.
+func jsonFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields[strings.ToLower(name)] = true
+	}
+
+	return fields
+}
+
+// strictWorkspace returns a request on the workspace like Workspace, whose responses are decoded with strictDecoder
+// when the provider sets strict. Only reads use it, to check the entities Terraform refreshes.
+func (c *Client) strictWorkspace(workspace string) *sling.Sling {
+	request := c.Workspace(workspace)
+	if c.strict {
+		request = request.ResponseDecoder(strictDecoder{})
+	}
+
+	return request
+}
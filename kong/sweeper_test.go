@@ -0,0 +1,79 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestMain runs the sweepers with go test ./kong -v -sweep=default, which delete the entities tagged with testAccTag
+// that interrupted acceptance test runs left on the Kong node of KONG_ADMIN_ADDR.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	// Entities are swept after those referencing them, Kong refusing to delete referenced entities. Credentials, ACL
+	// groups, targets and SNIs are deleted along with their consumer, upstream or certificate.
+	sweepers := []struct {
+		name         string
+		collection   string
+		dependencies []string
+	}{
+		{name: "kong_plugin", collection: "plugins"},
+		{name: "kong_route", collection: "routes", dependencies: []string{"kong_plugin"}},
+		{name: "kong_service", collection: "services", dependencies: []string{"kong_plugin", "kong_route"}},
+		{name: "kong_consumer", collection: "consumers", dependencies: []string{"kong_plugin"}},
+		{name: "kong_upstream", collection: "upstreams"},
+		{name: "kong_sni", collection: "snis"},
+		{name: "kong_certificate", collection: "certificates", dependencies: []string{"kong_service", "kong_sni", "kong_upstream"}},
+		{name: "kong_ca_certificate", collection: "ca_certificates", dependencies: []string{"kong_service"}},
+	}
+
+	for _, s := range sweepers {
+		resource.AddTestSweepers(s.name, &resource.Sweeper{
+			Name:         s.name,
+			Dependencies: s.dependencies,
+			F:            sweepTagged(s.collection),
+		})
+	}
+}
+
+// sweepTagged returns a sweeper deleting the entities of collection tagged with testAccTag. The region sweepers are
+// run for is not used, the Kong node being given by KONG_ADMIN_ADDR.
+func sweepTagged(collection string) func(string) error {
+	return func(_ string) error {
+		client, err := testAccClient()
+		if err != nil {
+			return err
+		}
+
+		var entities []struct {
+			ID string `json:"id"`
+		}
+
+		request := client.New().QueryStruct(&struct {
+			Tags string `url:"tags"`
+		}{Tags: testAccTag})
+
+		found, err := listAll(request, client.pageSize, collection, &entities)
+		if err != nil || !found {
+			return err
+		}
+
+		for _, e := range entities {
+			response, err := client.New().Delete(collection + "/" + pathSegment(e.ID)).ReceiveSuccess(nil)
+			if err != nil {
+				return fmt.Errorf("error while deleting %s/%s: %s", collection, e.ID, err)
+			}
+
+			if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
+				return fmt.Errorf("unexpected status code received while deleting %s/%s: %s", collection, e.ID, response.Status)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,429 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+//go:generate go run ../tools/plugingen -schemas schemas -output .
+
+// typedPlugin : a Kong plugin exposed as its own resource, with a config block described by a Terraform schema
+//
+// Every attribute of the config block maps to the plugin config property of the same name. Attributes are always sent,
+// with their Default matching Kong's own default when not written, except for Optional and Computed attributes which
+// are only sent when written in the configuration so that Kong keeps applying its defaults otherwise.
+type typedPlugin struct {
+	// Name is the name of the plugin in Kong.
+	Name string
+
+	// Config is the schema of the config block.
+	Config map[string]*schema.Schema
+
+	// ConfigRequired makes the config block mandatory for plugins having required properties.
+	ConfigRequired bool
+
+	// ToKong and FromKong convert the properties whose Terraform layout differs from Kong's.
+	ToKong   func(config map[string]interface{}) error
+	FromKong func(config map[string]interface{})
+
+	// CustomizeDiff validates the config block at plan time.
+	CustomizeDiff schema.CustomizeDiffFunc
+
+	// PreviousConfig is the schema of the config block at schema version 0, for plugins whose config block changed in
+	// a way Terraform can't read from older states. UpgradeConfig converts the config block of such states in place.
+	PreviousConfig map[string]*schema.Schema
+	UpgradeConfig  func(config map[string]interface{})
+}
+
+func resourceKongTypedPlugin(t typedPlugin) *schema.Resource {
+	pluginSchema := pluginBaseSchema()
+
+	pluginSchema["config"] = t.configSchema(t.Config)
+
+	diffs := []schema.CustomizeDiffFunc{
+		computeUpdatedAtOnChange,
+		validateReferences(pluginScopeReferences...),
+		preventProtectedReplacement("plugin", pluginForceNew...),
+	}
+	if t.CustomizeDiff != nil {
+		diffs = append(diffs, t.CustomizeDiff)
+	}
+
+	pc := pluginConfig{
+		name: func(*schema.ResourceData) string {
+			return t.Name
+		},
+		expand:  t.expand,
+		flatten: t.flatten,
+	}
+
+	resource := &schema.Resource{
+		CreateContext: pc.create,
+		ReadContext:   pc.read,
+		UpdateContext: pc.update,
+		DeleteContext: resourceKongPluginDelete,
+
+		Timeouts: resourceTimeouts(5 * time.Minute),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceEntity("plugin"),
+		},
+
+		CustomizeDiff: customdiff.All(diffs...),
+
+		Schema: pluginSchema,
+	}
+
+	if t.UpgradeConfig != nil {
+		previous := pluginBaseSchema()
+		previous["config"] = t.configSchema(t.PreviousConfig)
+
+		resource.SchemaVersion = 1
+		resource.StateUpgraders = []schema.StateUpgrader{{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: previous}).CoreConfigSchema().ImpliedType(),
+			Upgrade: t.upgradeState,
+		}}
+	}
+
+	return resource
+}
+
+// configSchema returns the config block of the plugin with the given attributes.
+func (t typedPlugin) configSchema(config map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Required:    t.ConfigRequired,
+		Optional:    !t.ConfigRequired,
+		Computed:    !t.ConfigRequired,
+		Elem:        &schema.Resource{Schema: config},
+		Description: fmt.Sprintf("The configuration of the %s plugin.", t.Name),
+	}
+}
+
+// upgradeState converts the config block of a state at schema version 0 with UpgradeConfig.
+func (t typedPlugin) upgradeState(_ context.Context, state map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	blocks, _ := state["config"].([]interface{})
+	for _, block := range blocks {
+		if config, ok := block.(map[string]interface{}); ok {
+			t.UpgradeConfig(config)
+		}
+	}
+
+	return state, nil
+}
+
+func (t typedPlugin) expand(d *schema.ResourceData) (map[string]interface{}, error) {
+	blocks := d.Get("config").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, nil
+	}
+
+	// Without a raw configuration, e.g. when not sent by Terraform, every attribute is considered as set.
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		raw = cty.DynamicVal
+	}
+	raw = rawConfigElement(rawConfigAttribute(raw, "config"), 0)
+
+	config := expandPluginConfigObject(t.Config, blocks[0].(map[string]interface{}), raw)
+
+	if t.ToKong != nil {
+		if err := t.ToKong(config); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+func (t typedPlugin) flatten(d *schema.ResourceData, plugin *Plugin) error {
+	if plugin.Configuration == nil {
+		return nil
+	}
+
+	if t.FromKong != nil {
+		t.FromKong(plugin.Configuration)
+	}
+
+	current := map[string]interface{}{}
+	if blocks := d.Get("config").([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+		current = blocks[0].(map[string]interface{})
+	}
+
+	return d.Set("config", []interface{}{flattenPluginConfigObject(t.Config, plugin.Configuration, current)})
+}
+
+// clearUnsetPluginConfig returns a ToKong function sending the given properties as null when not written in the
+// configuration, for properties which Kong would otherwise keep from the previous version of the plugin on update.
+func clearUnsetPluginConfig(keys ...string) func(config map[string]interface{}) error {
+	return func(config map[string]interface{}) error {
+		for _, k := range keys {
+			if _, ok := config[k]; !ok {
+				config[k] = nil
+			}
+		}
+
+		return nil
+	}
+}
+
+// expandPluginConfigObject converts the attributes of a block into plugin config properties.
+func expandPluginConfigObject(s map[string]*schema.Schema, values map[string]interface{}, raw cty.Value) map[string]interface{} {
+	config := make(map[string]interface{})
+
+	for k, attribute := range s {
+		if value, ok := expandPluginConfigValue(attribute, values[k], rawConfigAttribute(raw, k)); ok {
+			config[k] = value
+		}
+	}
+
+	return config
+}
+
+// expandPluginConfigValue converts a single attribute, reporting false when it must not be sent to Kong.
+func expandPluginConfigValue(attribute *schema.Schema, value interface{}, raw cty.Value) (interface{}, bool) {
+	if attribute.Computed && !rawConfigIsSet(raw) {
+		return nil, false
+	}
+
+	switch attribute.Type {
+	case schema.TypeString:
+		if s, _ := value.(string); s != "" {
+			return s, true
+		}
+		return nil, true
+	case schema.TypeMap:
+		m, _ := value.(map[string]interface{})
+		if m == nil {
+			m = map[string]interface{}{}
+		}
+		return m, true
+	case schema.TypeList, schema.TypeSet:
+		var items []interface{}
+		if set, ok := value.(*schema.Set); ok {
+			items = set.List()
+		} else {
+			items, _ = value.([]interface{})
+		}
+
+		elem, isBlock := attribute.Elem.(*schema.Resource)
+		if !isBlock {
+			if items == nil {
+				items = []interface{}{}
+			}
+			return items, true
+		}
+
+		if attribute.MaxItems == 1 {
+			// A block which isn't computed is reset when removed from the configuration.
+			if len(items) == 0 || items[0] == nil {
+				if attribute.Computed {
+					return nil, false
+				}
+				return expandPluginConfigObject(elem.Schema, map[string]interface{}{}, cty.NullVal(cty.DynamicPseudoType)), true
+			}
+			return expandPluginConfigObject(elem.Schema, items[0].(map[string]interface{}), rawConfigElement(raw, 0)), true
+		}
+
+		objects := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				objects = append(objects, expandPluginConfigObject(elem.Schema, m, rawConfigElement(raw, i)))
+			}
+		}
+		return objects, true
+	default:
+		return value, true
+	}
+}
+
+// flattenPluginConfigObject converts plugin config properties into the attributes of a block. Properties unknown to
+// the Kong node keep their current value.
+func flattenPluginConfigObject(s map[string]*schema.Schema, config map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	for k, attribute := range s {
+		value, ok := config[k]
+		if !ok {
+			if c, ok := current[k]; ok {
+				values[k] = c
+			}
+			continue
+		}
+
+		values[k] = flattenPluginConfigValue(attribute, value, current[k])
+	}
+
+	return values
+}
+
+func flattenPluginConfigValue(attribute *schema.Schema, value interface{}, current interface{}) interface{} {
+	switch attribute.Type {
+	case schema.TypeString:
+		// Vault references may come back resolved or encrypted, and secrets may be masked by Kong.
+		if c, ok := current.(string); ok && c != "" && (isVaultReference(c) || attribute.Sensitive) {
+			return c
+		}
+		switch v := value.(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		default:
+			return encodeConfigValue(v)
+		}
+	case schema.TypeInt:
+		if n, ok := value.(float64); ok {
+			return int(n)
+		}
+		return 0
+	case schema.TypeFloat:
+		if n, ok := value.(float64); ok {
+			return n
+		}
+		return 0.0
+	case schema.TypeBool:
+		b, _ := value.(bool)
+		return b
+	case schema.TypeMap:
+		m, _ := value.(map[string]interface{})
+		values := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if s, ok := v.(string); ok {
+				values[k] = s
+			} else {
+				values[k] = encodeConfigValue(v)
+			}
+		}
+		return values
+	case schema.TypeList, schema.TypeSet:
+		var currentItems []interface{}
+		if set, ok := current.(*schema.Set); ok {
+			currentItems = set.List()
+		} else {
+			currentItems, _ = current.([]interface{})
+		}
+
+		elem, isBlock := attribute.Elem.(*schema.Resource)
+		if isBlock && attribute.MaxItems == 1 {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return []interface{}{}
+			}
+			c := map[string]interface{}{}
+			if len(currentItems) > 0 && currentItems[0] != nil {
+				c = currentItems[0].(map[string]interface{})
+			}
+
+			// Kong returns empty objects for the blocks left out of the configuration.
+			object := flattenPluginConfigObject(elem.Schema, m, c)
+			if !attribute.Computed && len(currentItems) == 0 && isEmptyPluginConfigObject(object) {
+				return []interface{}{}
+			}
+			return []interface{}{object}
+		}
+
+		items, _ := value.([]interface{})
+		values := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			var c interface{}
+			if i < len(currentItems) {
+				c = currentItems[i]
+			}
+
+			if isBlock {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				cm, _ := c.(map[string]interface{})
+				values = append(values, flattenPluginConfigObject(elem.Schema, m, cm))
+			} else {
+				values = append(values, flattenPluginConfigValue(attribute.Elem.(*schema.Schema), item, c))
+			}
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// isEmptyPluginConfigObject reports whether every attribute of a flattened block has its zero value.
+func isEmptyPluginConfigObject(object map[string]interface{}) bool {
+	for _, v := range object {
+		switch value := v.(type) {
+		case nil:
+		case string:
+			if value != "" {
+				return false
+			}
+		case int:
+			if value != 0 {
+				return false
+			}
+		case float64:
+			if value != 0 {
+				return false
+			}
+		case bool:
+			if value {
+				return false
+			}
+		case map[string]interface{}:
+			if len(value) > 0 {
+				return false
+			}
+		case []interface{}:
+			if len(value) > 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// rawConfigAttribute returns the attribute of an object from the raw configuration. Unknown values, also used when
+// the raw configuration is not available, are considered as set.
+func rawConfigAttribute(raw cty.Value, name string) cty.Value {
+	if raw.Type() == cty.NilType || !raw.IsKnown() {
+		return cty.DynamicVal
+	}
+
+	if raw.IsNull() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return raw.GetAttr(name)
+}
+
+// rawConfigElement returns the element of a list from the raw configuration, see rawConfigAttribute.
+func rawConfigElement(raw cty.Value, index int) cty.Value {
+	if raw.Type() == cty.NilType || !raw.IsKnown() {
+		return cty.DynamicVal
+	}
+
+	if !(raw.Type().IsListType() || raw.Type().IsTupleType()) {
+		return cty.DynamicVal
+	}
+
+	if raw.IsNull() || index >= raw.LengthInt() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return raw.Index(cty.NumberIntVal(int64(index)))
+}
+
+// rawConfigIsSet reports whether the attribute was written in the configuration.
+func rawConfigIsSet(raw cty.Value) bool {
+	return !raw.IsKnown() || !raw.IsNull()
+}
@@ -0,0 +1,28 @@
+// Code generated by plugingen. DO NOT EDIT.
+
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// generatedPluginResources are the typed plugin resources generated from the bundled plugin schemas.
+var generatedPluginResources = map[string]func() *schema.Resource{
+	"kong_plugin_ai_prompt_decorator":     resourceKongPluginAiPromptDecoratorGenerated,
+	"kong_plugin_ai_prompt_template":      resourceKongPluginAiPromptTemplateGenerated,
+	"kong_plugin_ai_proxy":                resourceKongPluginAiProxyGenerated,
+	"kong_plugin_ai_request_transformer":  resourceKongPluginAiRequestTransformerGenerated,
+	"kong_plugin_ai_response_transformer": resourceKongPluginAiResponseTransformerGenerated,
+	"kong_plugin_aws_lambda":              resourceKongPluginAwsLambdaGenerated,
+	"kong_plugin_azure_functions":         resourceKongPluginAzureFunctionsGenerated,
+	"kong_plugin_basic_auth":              resourceKongPluginBasicAuthGenerated,
+	"kong_plugin_datadog":                 resourceKongPluginDatadogGenerated,
+	"kong_plugin_grpc_gateway":            resourceKongPluginGrpcGatewayGenerated,
+	"kong_plugin_http_log":                resourceKongPluginHttpLogGenerated,
+	"kong_plugin_loggly":                  resourceKongPluginLogglyGenerated,
+	"kong_plugin_oauth2":                  resourceKongPluginOauth2Generated,
+	"kong_plugin_proxy_cache":             resourceKongPluginProxyCacheGenerated,
+	"kong_plugin_rate_limiting":           resourceKongPluginRateLimitingGenerated,
+	"kong_plugin_session":                 resourceKongPluginSessionGenerated,
+	"kong_plugin_zipkin":                  resourceKongPluginZipkinGenerated,
+}
@@ -0,0 +1,18 @@
+resource "kong_canary_release" "canary_release" {
+  upstream       = kong_upstream.upstream.id
+  stable_targets = ["10.0.0.10:8000", "10.0.0.11:8000"]
+  canary_targets = ["10.0.1.10:8000"]
+
+  // Shift 10% more of the traffic every 5 minutes until half of it goes to the canary target
+  percentage    = 50
+  steps         = 5
+  step_interval = "5m"
+
+  health_check        = true
+  rollback_on_failure = true
+
+  timeouts {
+    create = "1h"
+    update = "1h"
+  }
+}
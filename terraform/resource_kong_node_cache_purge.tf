@@ -0,0 +1,6 @@
+resource "kong_node_cache_purge" "cache_purge" {
+  // Purge the cache once the credentials of the consumer changed
+  triggers = {
+    key_auth = kong_consumer_key_auth_credential.key-auth-credential.id
+  }
+}
@@ -37,8 +37,10 @@ resource "kong_plugin" "rate_limiting_on_consumer" {
 }
 
 
-// Prometheus plugin with default configuration
+// Prometheus plugin with default configuration and protocols
 resource "kong_plugin" "prometheus" {
-  name      = "prometheus"
-  protocols = ["grpc", "grpcs", "http", "https"]
+  name = "prometheus"
+
+  // Take over the plugin if it was already enabled globally outside of Terraform
+  adopt_on_conflict = true
 }
@@ -0,0 +1,9 @@
+// Only let consumers of the ACL group example use the route
+resource "kong_plugin_acl" "acl_on_route" {
+  route = kong_route.route.id
+
+  config {
+    allow              = [kong_consumer_acl_group.acl_group.group]
+    hide_groups_header = true
+  }
+}
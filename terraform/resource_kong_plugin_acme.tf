@@ -0,0 +1,16 @@
+// Issue certificates for the example.com subdomains from Let's Encrypt, stored in redis for every Kong node
+resource "kong_plugin_acme" "acme" {
+  config {
+    account_email = "ops@example.com"
+    tos_accepted  = true
+    domains       = ["*.example.com"]
+    storage       = "redis"
+
+    storage_config {
+      redis {
+        host = "redis"
+        port = 6379
+      }
+    }
+  }
+}
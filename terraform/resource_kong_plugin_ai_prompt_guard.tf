@@ -0,0 +1,12 @@
+// Reject prompts sent to the route asking for credentials
+resource "kong_plugin_ai_prompt_guard" "ai_prompt_guard_on_route" {
+  route = kong_route.route.id
+
+  config {
+    deny_patterns = [
+      "(?i).*password.*",
+      "(?i).*api[ _-]?key.*",
+    ]
+    allow_all_conversation_history = true
+  }
+}
@@ -0,0 +1,9 @@
+// Deny crawlers on the service while letting the internal monitoring through
+resource "kong_plugin_bot_detection" "bot_detection_on_service" {
+  service = kong_service.service.id
+
+  config {
+    allow = ["^internal-monitoring/.*"]
+    deny  = ["(?i)scrapy", "(?i)python-requests"]
+  }
+}
@@ -0,0 +1,8 @@
+// Tag every request with a correlation ID, sent back to the clients
+resource "kong_plugin_correlation_id" "correlation_id" {
+  config {
+    header_name     = "X-Request-Id"
+    generator       = "uuid"
+    echo_downstream = true
+  }
+}
@@ -0,0 +1,13 @@
+// Allow browsers from the example.com domains to call the service
+resource "kong_plugin_cors" "cors_on_service" {
+  service = kong_service.service.id
+
+  config {
+    origins         = ["https://example.com", "https://.*\\.example\\.com"]
+    methods         = ["GET", "POST", "OPTIONS"]
+    headers         = ["Authorization", "Content-Type"]
+    exposed_headers = ["X-Request-Id"]
+    credentials     = true
+    max_age         = 3600
+  }
+}
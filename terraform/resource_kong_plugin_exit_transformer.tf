@@ -0,0 +1,14 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_exit_transformer" "exit_transformer_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    functions = [
+#      <<-EOT
+#        return function(status, body, headers)
+#          return status, { error = { status = status, message = body and body.message } }, headers
+#        end
+#      EOT
+#    ]
+#  }
+#}
@@ -0,0 +1,14 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_forward_proxy" "forward_proxy_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    http_proxy_host  = "proxy.example.com"
+#    http_proxy_port  = 3128
+#    https_proxy_host = "proxy.example.com"
+#    https_proxy_port = 3128
+#    auth_username    = "kong"
+#    auth_password    = var.proxy_password
+#    x_headers        = "transparent"
+#  }
+#}
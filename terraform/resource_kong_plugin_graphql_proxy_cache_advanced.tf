@@ -0,0 +1,15 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_graphql_proxy_cache_advanced" "graphql_proxy_cache_advanced_on_route" {
+#  route = kong_route.route.id
+#
+#  config {
+#    strategy     = "redis"
+#    cache_ttl    = 30
+#    vary_headers = ["authorization"]
+#
+#    redis {
+#      host = "redis"
+#      port = 6379
+#    }
+#  }
+#}
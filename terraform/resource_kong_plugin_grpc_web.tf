@@ -0,0 +1,9 @@
+// Let browsers call the gRPC service through the route, using JSON payloads
+resource "kong_plugin_grpc_web" "grpc_web_on_route" {
+  route = kong_route.route.id
+
+  config {
+    proto               = "/usr/local/kong/protos/hello.proto"
+    allow_origin_header = "https://example.com"
+  }
+}
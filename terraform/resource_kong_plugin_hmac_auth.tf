@@ -0,0 +1,11 @@
+// Require HMAC signed requests on the service, signing at least the date and the request line
+resource "kong_plugin_hmac_auth" "hmac_auth_on_service" {
+  service = kong_service.service.id
+
+  config {
+    clock_skew            = 60
+    validate_request_body = true
+    enforce_headers       = ["date", "request-line"]
+    algorithms            = ["hmac-sha256", "hmac-sha512"]
+  }
+}
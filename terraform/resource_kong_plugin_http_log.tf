@@ -0,0 +1,16 @@
+// Send the logs of the service to an HTTP collector, generated from the http-log schema
+resource "kong_plugin_http_log" "http_log_on_service" {
+  service = kong_service.service.id
+
+  config {
+    http_endpoint = "http://logs.example.com/kong"
+
+    headers = {
+      "x-source" = "kong"
+    }
+
+    queue {
+      max_batch_size = 100
+    }
+  }
+}
@@ -0,0 +1,11 @@
+// Only let clients from the private networks use the route
+resource "kong_plugin_ip_restriction" "ip_restriction_on_route" {
+  route = kong_route.route.id
+
+  config {
+    allow   = ["10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"]
+    deny    = ["10.0.0.1"]
+    status  = 404
+    message = "Not Found"
+  }
+}
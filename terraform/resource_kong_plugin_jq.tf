@@ -0,0 +1,12 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_jq" "jq_on_route" {
+#  route = kong_route.route.id
+#
+#  config {
+#    response_jq_program = <<-EOT
+#      .data | map({id, name})
+#    EOT
+#
+#    response_if_status_code = [200]
+#  }
+#}
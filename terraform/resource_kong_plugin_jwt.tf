@@ -0,0 +1,11 @@
+// Require a JWT on the route, read from the Authorization header or the "token" cookie
+resource "kong_plugin_jwt" "jwt_on_route" {
+  route = kong_route.route.id
+
+  config {
+    header_names       = ["authorization"]
+    cookie_names       = ["token"]
+    claims_to_verify   = ["exp"]
+    maximum_expiration = 3600
+  }
+}
@@ -0,0 +1,19 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_jwt_signer" "jwt_signer_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    access_token_jwks_uri           = "https://idp.example.com/.well-known/jwks.json"
+#    access_token_scopes_required    = ["api:read"]
+#    access_token_signing_algorithm  = "RS256"
+#    access_token_keyset             = "kong"
+#    access_token_consumer_claim     = ["sub"]
+#    access_token_consumer_by        = ["username"]
+#    channel_token_optional          = true
+#
+#    set_access_token_claims = {
+#      iss = "https://kong.example.com"
+#    }
+#    remove_access_token_claims = ["email"]
+#  }
+#}
@@ -0,0 +1,29 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_kafka_log" "kafka_log" {
+#  config {
+#    topic = "kong-logs"
+#
+#    bootstrap_servers {
+#      host = "kafka-1"
+#      port = 9092
+#    }
+#
+#    bootstrap_servers {
+#      host = "kafka-2"
+#      port = 9092
+#    }
+#
+#    authentication {
+#      strategy  = "sasl"
+#      mechanism = "SCRAM-SHA-512"
+#      user      = "kong"
+#      password  = var.kafka_password
+#    }
+#
+#    security {
+#      ssl = true
+#    }
+#
+#    producer_request_acks = -1
+#  }
+#}
@@ -0,0 +1,19 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_kafka_upstream" "kafka_upstream_on_route" {
+#  route = kong_route.route.id
+#
+#  config {
+#    topic           = "orders"
+#    forward_method  = true
+#    forward_uri     = true
+#    forward_headers = true
+#
+#    bootstrap_servers {
+#      host = "kafka-1"
+#      port = 9092
+#    }
+#
+#    producer_async        = false
+#    producer_request_acks = -1
+#  }
+#}
@@ -0,0 +1,15 @@
+resource "kong_consumer" "anonymous" {
+  username = "anonymous"
+}
+
+// Require an API key on the service, letting unauthenticated requests through as the anonymous consumer
+resource "kong_plugin_key_auth" "key_auth_on_service" {
+  service = kong_service.service.id
+
+  config {
+    key_names        = ["apikey", "x-api-key"]
+    hide_credentials = true
+    key_in_query     = false
+    anonymous        = kong_consumer.anonymous.id
+  }
+}
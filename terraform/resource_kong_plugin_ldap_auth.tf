@@ -0,0 +1,31 @@
+// Authenticate users of the route against an LDAP directory
+resource "kong_plugin_ldap_auth" "ldap_auth_on_route" {
+  route = kong_route.route.id
+
+  config {
+    ldap_host        = "ldap.example.com"
+    ldap_port        = 636
+    ldaps            = true
+    verify_ldap_host = true
+    base_dn          = "ou=people,dc=example,dc=com"
+    attribute        = "uid"
+    header_type      = "basic"
+  }
+}
+
+// Only let members of the api-users group through, searching with a service account
+resource "kong_plugin_ldap_auth_advanced" "ldap_auth_advanced_on_service" {
+  service = kong_service.service.id
+
+  config {
+    ldap_host         = "ldap.example.com"
+    start_tls         = true
+    base_dn           = "ou=people,dc=example,dc=com"
+    attribute         = "uid"
+    bind_dn           = "cn=kong,ou=services,dc=example,dc=com"
+    ldap_password     = var.ldap_password
+    group_base_dn     = "ou=groups,dc=example,dc=com"
+    groups_required   = ["api-users"]
+    consumer_optional = true
+  }
+}
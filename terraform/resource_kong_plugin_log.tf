@@ -0,0 +1,44 @@
+// Log the requests of the service to a file on the Kong nodes, tagged with the environment
+resource "kong_plugin_file_log" "file_log_on_service" {
+  service = kong_service.service.id
+
+  config {
+    path   = "/var/log/kong/service.log"
+    reopen = true
+
+    custom_fields_by_lua = {
+      environment = "return os.getenv('KONG_ENVIRONMENT')"
+    }
+  }
+}
+
+// Ship the logs of the route to a log collector over TLS
+resource "kong_plugin_tcp_log" "tcp_log_on_route" {
+  route = kong_route.route.id
+
+  config {
+    host    = "logs.example.com"
+    port    = 6514
+    tls     = true
+    tls_sni = "logs.example.com"
+  }
+}
+
+// Send the logs of the consumer to a local UDP listener
+resource "kong_plugin_udp_log" "udp_log_on_consumer" {
+  consumer = kong_consumer.consumer.id
+
+  config {
+    host = "127.0.0.1"
+    port = 5140
+  }
+}
+
+// Send every request to the local syslog, raising the severity of errors
+resource "kong_plugin_syslog" "syslog" {
+  config {
+    client_errors_severity = "warning"
+    server_errors_severity = "err"
+    facility               = "local0"
+  }
+}
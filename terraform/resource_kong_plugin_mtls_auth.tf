@@ -0,0 +1,10 @@
+// Requires Kong Enterprise and the kong_ca_certificate example
+#resource "kong_plugin_mtls_auth" "mtls_auth_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    ca_certificates       = [kong_ca_certificate.ca_certificate.id]
+#    consumer_by           = ["username"]
+#    revocation_check_mode = "STRICT"
+#  }
+#}
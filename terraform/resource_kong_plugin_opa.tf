@@ -0,0 +1,11 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_opa" "opa_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    opa_host                      = "opa"
+#    opa_path                      = "/v1/data/kong/allow"
+#    include_consumer_in_opa_input = true
+#    include_route_in_opa_input    = true
+#  }
+#}
@@ -0,0 +1,21 @@
+// Send the traces of every request to an OpenTelemetry collector
+resource "kong_plugin_opentelemetry" "opentelemetry" {
+  config {
+    endpoint = "http://otel-collector:4318/v1/traces"
+
+    resource_attributes = {
+      "service.name" = "kong"
+    }
+
+    queue {
+      max_batch_size       = 200
+      max_coalescing_delay = 1
+    }
+
+    propagation {
+      extract        = ["w3c", "b3"]
+      inject         = ["preserve"]
+      default_format = "w3c"
+    }
+  }
+}
@@ -0,0 +1,11 @@
+// Export detailed metrics for the service, on top of the global prometheus plugin
+resource "kong_plugin_prometheus" "prometheus_on_service" {
+  service = kong_service.service.id
+
+  config {
+    per_consumer        = true
+    status_code_metrics = true
+    latency_metrics     = true
+    bandwidth_metrics   = true
+  }
+}
@@ -0,0 +1,18 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_proxy_cache_advanced" "proxy_cache_advanced_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    strategy      = "redis"
+#    cache_ttl     = 60
+#    content_type  = ["application/json"]
+#    vary_headers  = ["accept-language"]
+#    bypass_on_err = true
+#
+#    redis {
+#      sentinel_master    = "mymaster"
+#      sentinel_role      = "master"
+#      sentinel_addresses = ["sentinel-1:26379", "sentinel-2:26379", "sentinel-3:26379"]
+#    }
+#  }
+#}
@@ -0,0 +1,30 @@
+// Limit consumers of the service to 10 requests per second and 500 requests per minute, counted in redis
+resource "kong_plugin_rate_limiting_advanced" "rate_limiting_advanced_on_service" {
+  service = kong_service.service.id
+
+  config {
+    limit       = [10, 500]
+    window_size = [1, 60]
+    identifier  = "consumer"
+    strategy    = "redis"
+    sync_rate   = 0
+
+    redis {
+      host = "redis"
+      port = 6379
+    }
+  }
+}
+
+// Limit requests on the route by the value of a header
+resource "kong_plugin_rate_limiting_advanced" "rate_limiting_advanced_on_route" {
+  route = kong_route.route.id
+
+  config {
+    limit       = [100]
+    window_size = [60]
+    identifier  = "header"
+    header_name = "X-Client-Id"
+    strategy    = "local"
+  }
+}
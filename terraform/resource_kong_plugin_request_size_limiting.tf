@@ -0,0 +1,10 @@
+// Reject request bodies larger than 512 kilobytes on the route
+resource "kong_plugin_request_size_limiting" "request_size_limiting_on_route" {
+  route = kong_route.route.id
+
+  config {
+    allowed_payload_size   = 512
+    size_unit              = "kilobytes"
+    require_content_length = true
+  }
+}
@@ -0,0 +1,11 @@
+// Put the service in maintenance mode, sending an HTML page to every request
+resource "kong_plugin_request_termination" "maintenance_on_service" {
+  service = kong_service.service.id
+  enabled = var.maintenance
+
+  config {
+    status_code  = 503
+    content_type = "text/html; charset=utf-8"
+    body         = "<html><body><h1>Down for maintenance</h1></body></html>"
+  }
+}
@@ -0,0 +1,40 @@
+// Rewrite the requests sent to the service
+resource "kong_plugin_request_transformer" "request_transformer_on_service" {
+  service = kong_service.service.id
+
+  config {
+    remove {
+      headers     = ["cookie"]
+      querystring = ["debug"]
+    }
+
+    rename {
+      headers = {
+        "x-client-id" = "x-consumer-custom-id"
+      }
+    }
+
+    add {
+      headers = {
+        "x-forwarded-prefix" = "/api"
+      }
+      querystring = {
+        "source" = "kong"
+      }
+    }
+  }
+}
+
+// Append values to headers which may already be present, the same header more than once
+resource "kong_plugin_request_transformer" "request_transformer_on_route" {
+  route = kong_route.route.id
+
+  config {
+    append {
+      headers = [
+        "x-via:kong",
+        "x-via:terraform",
+      ]
+    }
+  }
+}
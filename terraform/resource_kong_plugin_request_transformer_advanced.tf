@@ -0,0 +1,16 @@
+// Requires Kong Enterprise
+#resource "kong_plugin_request_transformer_advanced" "request_transformer_advanced_on_route" {
+#  route = kong_route.route.id
+#
+#  config {
+#    allow {
+#      body = ["name", "email"]
+#    }
+#
+#    add {
+#      headers = {
+#        "x-original-host" = "$(headers.host)"
+#      }
+#    }
+#  }
+#}
@@ -0,0 +1,24 @@
+// Limit the videos and images the upstream service reports serving to every consumer
+resource "kong_plugin_response_ratelimiting" "response_ratelimiting_on_service" {
+  service = kong_service.service.id
+
+  config {
+    limit {
+      name   = "videos"
+      minute = 10
+      day    = 100
+    }
+
+    limit {
+      name   = "images"
+      second = 5
+    }
+
+    policy = "redis"
+
+    redis {
+      host = "redis"
+      port = 6379
+    }
+  }
+}
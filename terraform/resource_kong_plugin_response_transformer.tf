@@ -0,0 +1,25 @@
+// Rewrite the responses of the route
+resource "kong_plugin_response_transformer" "response_transformer_on_route" {
+  route = kong_route.route.id
+
+  config {
+    remove {
+      headers = ["server", "x-powered-by"]
+      json    = ["internal_id"]
+    }
+
+    add {
+      headers = {
+        "x-frame-options" = "DENY"
+      }
+      json = {
+        "api_version" = "2"
+        "deprecated"  = "false"
+      }
+      json_types = {
+        "api_version" = "number"
+        "deprecated"  = "boolean"
+      }
+    }
+  }
+}
@@ -0,0 +1,25 @@
+// Reject requests of the route without a tenant header, before the other plugins run
+resource "kong_plugin_pre_function" "pre_function_on_route" {
+  route = kong_route.route.id
+
+  config {
+    access = [
+      <<-EOT
+        if not kong.request.get_header("x-tenant") then
+          return kong.response.exit(400, { message = "missing x-tenant header" })
+        end
+      EOT
+    ]
+  }
+}
+
+// Add the Kong node to the responses of the route, after the other plugins ran
+resource "kong_plugin_post_function" "post_function_on_route" {
+  route = kong_route.route.id
+
+  config {
+    header_filter = [
+      "kong.response.set_header('x-kong-node', kong.node.get_hostname())",
+    ]
+  }
+}
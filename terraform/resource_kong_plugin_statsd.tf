@@ -0,0 +1,27 @@
+// Send the request metrics of the service to a StatsD agent
+resource "kong_plugin_statsd" "statsd_on_service" {
+  service = kong_service.service.id
+
+  config {
+    host   = "statsd"
+    prefix = "kong.example"
+
+    metrics {
+      name        = "request_count"
+      stat_type   = "counter"
+      sample_rate = 1
+    }
+
+    metrics {
+      name      = "latency"
+      stat_type = "timer"
+    }
+
+    metrics {
+      name                = "status_count_per_user"
+      stat_type           = "counter"
+      sample_rate         = 1
+      consumer_identifier = "username"
+    }
+  }
+}
@@ -0,0 +1,9 @@
+// Requires Kong Enterprise and a vault entity
+#resource "kong_plugin_vault_auth" "vault_auth_on_service" {
+#  service = kong_service.service.id
+#
+#  config {
+#    vault            = "7b0ad9b5-7b4e-4ef5-8f0c-5b1f3c5c1e52"
+#    hide_credentials = true
+#  }
+#}
@@ -8,14 +8,14 @@ resource "kong_route" "route" {
   hosts     = ["example.com", "foo.test"]
   paths     = ["/foo", "/bar"]
 
-  # header {
-  #    name   = "x-my-header"
-  #    values = ["foo", "bar"]
-  #  }
-  #  header {
-  #    name   = "x-another-header"
-  #    values = ["bla"]
-  #  }
+  header {
+    name   = "x-api-version"
+    values = ["v1", "v2"]
+  }
+  header {
+    name   = "x-tenant"
+    values = ["~*^tenant-[0-9]+$"]
+  }
 
   https_redirect_status_code = 426
   regex_priority             = 1
@@ -27,3 +27,55 @@ resource "kong_route" "route" {
   tags                       = ["user-level", "low-priority"]
 
 }
+
+resource "kong_route" "stream_route" {
+
+  service = kong_service.service.id
+
+  name      = "my-stream-route"
+  protocols = ["tls"]
+  snis      = ["example.com"]
+
+  sources {
+    ip = "10.0.0.0/8"
+  }
+
+  destinations {
+    ip   = "192.168.1.10"
+    port = 8443
+  }
+
+}
+
+resource "kong_route" "grpc_route" {
+
+  service = kong_service.service.id
+
+  name       = "my-grpc-route"
+  protocols  = ["grpc", "grpcs"]
+  paths      = ["/helloworld.Greeter/SayHello"]
+  strip_path = false
+
+}
+
+resource "kong_route" "route_by_service_name" {
+
+  service_name = "service-managed-by-deck"
+
+  name      = "my-route-by-service-name"
+  protocols = ["http", "https"]
+  paths     = ["/deck"]
+
+}
+
+// Requires Kong 3.0 or later with router_flavor = expressions
+#resource "kong_route" "expression_route" {
+#
+#  service = kong_service.service.id
+#
+#  name       = "my-expression-route"
+#  protocols  = ["http", "https"]
+#  expression = "http.path ^= \"/foo\" && http.method == \"GET\""
+#  priority   = 100
+#
+#}
@@ -10,16 +10,23 @@ resource "kong_service" "service" {
   write_timeout   = 60000
   read_timeout    = 60000
   tags            = ["user-level", "low-priority"]
-
-  client_certificate = null
-  tls_verify         = false
-  tls_verify_depth   = null
-  ca_certificates    = null
+  enabled         = true
+  force_destroy   = false
+  protect         = false
 
   //  Works only with HTTPS protocol
   //  client_certificate = kong_certificate.certificate.id
-  //  tls_verify = false
-  //  tls_verify_depth = null
-  //  ca_certificates = ["4e3ad2e4-0bc4-4638-8e34-c84a417ba39b", "51e77dc2-8f3e-4afa-9d0e-0e3bbbcfd515"]
+  //  tls_verify         = true
+  //  tls_verify_depth   = 2
+  //  ca_certificates    = ["4e3ad2e4-0bc4-4638-8e34-c84a417ba39b", "51e77dc2-8f3e-4afa-9d0e-0e3bbbcfd515"]
+
+  timeouts {
+    create = "2m"
+    delete = "30m"
+  }
+}
 
+resource "kong_service" "service_url" {
+  name = "my_service_url"
+  url  = "https://example.com:8443/some_api"
 }
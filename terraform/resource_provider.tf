@@ -2,4 +2,7 @@ provider "kong" {
   address  = "http://localhost:8001"
   username = "username"
   password = "password"
+
+  validate_references        = true
+  fail_on_concurrent_changes = true
 }
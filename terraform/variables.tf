@@ -7,3 +7,26 @@ variable "consumer_custom_id" {
   type    = string
   default = "my-custom-id"
 }
+
+variable "ldap_password" {
+  type      = string
+  default   = "change-me"
+  sensitive = true
+}
+
+variable "maintenance" {
+  type    = bool
+  default = false
+}
+
+variable "kafka_password" {
+  type      = string
+  default   = "change-me"
+  sensitive = true
+}
+
+variable "proxy_password" {
+  type      = string
+  default   = "change-me"
+  sensitive = true
+}
@@ -0,0 +1,471 @@
+// plugingen generates the typed plugin resources of the provider from the schemas of the Kong plugins.
+//
+// The schemas are the JSON documents returned by the /schemas/plugins/{name} endpoint of the Admin API, bundled in a
+// directory so that generating doesn't require a Kong node. Given an Admin API address, the schemas of the plugins
+// enabled on the node, or of the given plugins, are fetched into that directory first. From the kong directory:
+//
+//	go generate
+//	go run ../tools/plugingen -schemas schemas -output . -address http://localhost:8001
+//
+// Every plugin produces a resourceKongPlugin<Name>Generated function built with resourceKongTypedPlugin, registered
+// in the generatedPluginResources map unless a hand-written resource of the same name exists.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	schemas := flag.String("schemas", "schemas", "directory of the plugin schemas")
+	output := flag.String("output", ".", "directory of the generated sources")
+	address := flag.String("address", "", "address of a Kong Admin API to fetch the schemas from")
+	plugins := flag.String("plugins", "", "comma separated plugins to fetch, defaults to the plugins enabled on the node")
+	flag.Parse()
+
+	if *address != "" {
+		if err := fetchSchemas(*address, *plugins, *schemas); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := generate(*schemas, *output); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fetchSchemas saves the schemas of the plugins into the schemas directory.
+func fetchSchemas(address string, plugins string, dir string) error {
+	address = strings.TrimSuffix(address, "/")
+
+	var names []string
+	if plugins != "" {
+		names = strings.Split(plugins, ",")
+	} else {
+		enabled := &struct {
+			EnabledPlugins []string `json:"enabled_plugins"`
+		}{}
+		if err := getJSON(address+"/plugins/enabled", enabled); err != nil {
+			return err
+		}
+		names = enabled.EnabledPlugins
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		var schema json.RawMessage
+		if err := getJSON(address+"/schemas/plugins/"+name, &schema); err != nil {
+			return err
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, schema, "", "  "); err != nil {
+			return err
+		}
+		indented.WriteString("\n")
+
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), indented.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getJSON(url string, v interface{}) error {
+	response, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("unexpected status code received from %s: %s %s", url, response.Status, body)
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+// generate writes a source file for every schema, and the file registering them.
+func generate(schemas string, output string) error {
+	paths, err := filepath.Glob(filepath.Join(schemas, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	resources := make(map[string]string)
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		schema := &entitySchema{}
+		if err := json.Unmarshal(data, schema); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+
+		source, err := generatePlugin(name, filepath.ToSlash(path), schema)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(output, "zz_generated_plugin_"+snakeCase(name)+".go"), source, 0644); err != nil {
+			return err
+		}
+
+		resources["kong_plugin_"+snakeCase(name)] = functionName(name)
+	}
+
+	source, err := generateRegistry(resources)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(output, "zz_generated_plugins.go"), source, 0644)
+}
+
+// entitySchema : the schema of a plugin, or of a record
+type entitySchema struct {
+	Fields []fieldEntry `json:"fields"`
+}
+
+// fieldEntry : a field of a schema, encoded by Kong as an object holding the field under its name
+type fieldEntry struct {
+	Name  string
+	Field *field
+}
+
+func (e *fieldEntry) UnmarshalJSON(data []byte) error {
+	entry := make(map[string]*field)
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+
+	if len(entry) != 1 {
+		return fmt.Errorf("expected a single field, got %s", data)
+	}
+
+	for name, f := range entry {
+		e.Name = name
+		e.Field = f
+	}
+
+	return nil
+}
+
+// field : the properties of a field used to generate its Terraform schema
+type field struct {
+	Type          string        `json:"type"`
+	Required      bool          `json:"required"`
+	Auto          bool          `json:"auto"`
+	Default       interface{}   `json:"default"`
+	OneOf         []interface{} `json:"one_of"`
+	Between       []float64     `json:"between"`
+	LenMin        int           `json:"len_min"`
+	Encrypted     bool          `json:"encrypted"`
+	Referenceable bool          `json:"referenceable"`
+	Description   string        `json:"description"`
+	Deprecation   interface{}   `json:"deprecation"`
+	Elements      *field        `json:"elements"`
+	Values        *field        `json:"values"`
+	Fields        []fieldEntry  `json:"fields"`
+}
+
+func generatePlugin(name string, path string, schema *entitySchema) ([]byte, error) {
+	var config *field
+	for _, entry := range schema.Fields {
+		if entry.Name == "config" {
+			config = entry.Field
+		}
+	}
+
+	if config == nil || config.Type != "record" {
+		return nil, fmt.Errorf("no config record in the schema of %s", name)
+	}
+
+	g := &generator{plugin: name}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by plugingen from %s. DO NOT EDIT.\n\n", path)
+	b.WriteString("package kong\n\n")
+	b.WriteString("import (\n")
+	b.WriteString("\t\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"\n")
+	b.WriteString("\t\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation\"\n")
+	b.WriteString(")\n\n")
+	fmt.Fprintf(&b, "func %s() *schema.Resource {\n", functionName(name))
+	b.WriteString("\treturn resourceKongTypedPlugin(typedPlugin{\n")
+	fmt.Fprintf(&b, "\t\tName: %q,\n", name)
+	if hasRequiredField(config.Fields) {
+		b.WriteString("\t\tConfigRequired: true,\n")
+	}
+	b.WriteString("\t\tConfig: ")
+	g.writeFields(&b, config.Fields)
+	b.WriteString(",\n\t})\n}\n")
+
+	source := b.Bytes()
+	if !g.validation {
+		source = bytes.Replace(source, []byte("\t\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation\"\n"), nil, 1)
+	}
+
+	return format.Source(source)
+}
+
+func generateRegistry(resources map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by plugingen. DO NOT EDIT.\n\n")
+	b.WriteString("package kong\n\n")
+	b.WriteString("import (\n\t\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"\n)\n\n")
+	b.WriteString("// generatedPluginResources are the typed plugin resources generated from the bundled plugin schemas.\n")
+	b.WriteString("var generatedPluginResources = map[string]func() *schema.Resource{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t%q: %s,\n", name, resources[name])
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// generator writes the Terraform schema of the fields of a plugin.
+type generator struct {
+	plugin string
+
+	// validation reports whether the validation package is used.
+	validation bool
+}
+
+// attributeNameRegexp matches the names Terraform accepts for attributes.
+var attributeNameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func (g *generator) writeFields(b *bytes.Buffer, fields []fieldEntry) {
+	b.WriteString("map[string]*schema.Schema{\n")
+
+	for _, entry := range fields {
+		if entry.Field.Deprecation != nil {
+			continue
+		}
+
+		if !attributeNameRegexp.MatchString(entry.Name) {
+			log.Printf("%s: skipping %s, which is not a valid attribute name", g.plugin, entry.Name)
+			continue
+		}
+
+		var s bytes.Buffer
+		if !g.writeField(&s, entry.Field, true) {
+			log.Printf("%s: skipping %s, %s fields are not supported", g.plugin, entry.Name, entry.Field.Type)
+			continue
+		}
+
+		fmt.Fprintf(b, "%q: %s,\n", entry.Name, strings.TrimPrefix(s.String(), "&schema.Schema"))
+	}
+
+	b.WriteString("}")
+}
+
+// writeField writes the schema of a field, reporting false when its type isn't supported. Attributes are those of
+// an element of a list when attribute is false.
+func (g *generator) writeField(b *bytes.Buffer, f *field, attribute bool) bool {
+	var elem bytes.Buffer
+
+	b.WriteString("&schema.Schema{\n")
+
+	switch f.Type {
+	case "string":
+		b.WriteString("Type: schema.TypeString,\n")
+	case "boolean":
+		b.WriteString("Type: schema.TypeBool,\n")
+	case "integer":
+		b.WriteString("Type: schema.TypeInt,\n")
+	case "number":
+		b.WriteString("Type: schema.TypeFloat,\n")
+	case "array", "set":
+		if f.Elements == nil {
+			return false
+		}
+
+		if f.Type == "set" && f.Elements.Type != "record" {
+			b.WriteString("Type: schema.TypeSet,\n")
+		} else {
+			b.WriteString("Type: schema.TypeList,\n")
+		}
+
+		if f.Elements.Type == "record" {
+			elem.WriteString("&schema.Resource{Schema: ")
+			g.writeFields(&elem, f.Elements.Fields)
+			elem.WriteString("}")
+		} else if !g.writeField(&elem, f.Elements, false) {
+			return false
+		}
+	case "map":
+		if f.Values == nil || f.Values.Type != "string" {
+			return false
+		}
+		b.WriteString("Type: schema.TypeMap,\n")
+		elem.WriteString("&schema.Schema{Type: schema.TypeString}")
+	case "record":
+		b.WriteString("Type: schema.TypeList,\n")
+		b.WriteString("MaxItems: 1,\n")
+		elem.WriteString("&schema.Resource{Schema: ")
+		g.writeFields(&elem, f.Fields)
+		elem.WriteString("}")
+	default:
+		return false
+	}
+
+	if elem.Len() > 0 {
+		fmt.Fprintf(b, "Elem: %s,\n", elem.String())
+	}
+
+	if attribute {
+		g.writeAttribute(b, f)
+	}
+
+	if validate := g.validateFunc(f); validate != "" {
+		fmt.Fprintf(b, "ValidateFunc: %s,\n", validate)
+	}
+
+	if attribute && f.Description != "" {
+		fmt.Fprintf(b, "Description: %q,\n", f.Description)
+	}
+
+	b.WriteString("}")
+
+	return true
+}
+
+// writeAttribute writes whether the field is required, and its default. Fields without a scalar default are optional
+// and computed, so that Kong keeps applying its defaults when they aren't written in the configuration, like the
+// fields Kong generates a value for.
+func (g *generator) writeAttribute(b *bytes.Buffer, f *field) {
+	def := defaultLiteral(f)
+
+	switch {
+	case f.Required && f.Default == nil && !f.Auto && f.Type != "record":
+		b.WriteString("Required: true,\n")
+	case def != "":
+		b.WriteString("Optional: true,\n")
+		fmt.Fprintf(b, "Default: %s,\n", def)
+	default:
+		b.WriteString("Optional: true,\n")
+		b.WriteString("Computed: true,\n")
+	}
+
+	if f.Encrypted {
+		b.WriteString("Sensitive: true,\n")
+	}
+}
+
+// defaultLiteral returns the Go literal of a scalar default, or an empty string.
+func defaultLiteral(f *field) string {
+	switch v := f.Default.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		if f.Type == "integer" {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		if f.Type == "number" {
+			s := strconv.FormatFloat(v, 'f', -1, 64)
+			if !strings.ContainsAny(s, ".e") {
+				s += ".0"
+			}
+			return s
+		}
+	}
+
+	return ""
+}
+
+// validateFunc returns the validation of the field, or an empty string.
+func (g *generator) validateFunc(f *field) string {
+	var validate string
+
+	switch f.Type {
+	case "string":
+		if len(f.OneOf) > 0 {
+			values := make([]string, 0, len(f.OneOf))
+			for _, v := range f.OneOf {
+				values = append(values, strconv.Quote(fmt.Sprint(v)))
+			}
+			validate = fmt.Sprintf("validation.StringInSlice([]string{%s}, false)", strings.Join(values, ", "))
+		} else if f.LenMin > 0 && !f.Referenceable {
+			validate = "validation.StringIsNotEmpty"
+		}
+	case "integer":
+		if len(f.OneOf) > 0 {
+			values := make([]string, 0, len(f.OneOf))
+			for _, v := range f.OneOf {
+				values = append(values, fmt.Sprint(v))
+			}
+			validate = fmt.Sprintf("validation.IntInSlice([]int{%s})", strings.Join(values, ", "))
+		} else if len(f.Between) == 2 {
+			validate = fmt.Sprintf("validation.IntBetween(%d, %d)", int64(f.Between[0]), int64(f.Between[1]))
+		}
+	case "number":
+		if len(f.Between) == 2 {
+			validate = fmt.Sprintf("validation.FloatBetween(%s, %s)",
+				strconv.FormatFloat(f.Between[0], 'f', -1, 64), strconv.FormatFloat(f.Between[1], 'f', -1, 64))
+		}
+	}
+
+	if validate != "" {
+		g.validation = true
+	}
+
+	return validate
+}
+
+// hasRequiredField reports whether the config block must be written, for plugins with required properties.
+func hasRequiredField(fields []fieldEntry) bool {
+	for _, entry := range fields {
+		if entry.Field.Required && entry.Field.Default == nil && !entry.Field.Auto && entry.Field.Type != "record" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// functionName returns the name of the function of a plugin, e.g. resourceKongPluginBasicAuthGenerated.
+func functionName(plugin string) string {
+	var b strings.Builder
+	b.WriteString("resourceKongPlugin")
+	for _, part := range strings.FieldsFunc(plugin, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	b.WriteString("Generated")
+
+	return b.String()
+}
+
+func snakeCase(plugin string) string {
+	return strings.ReplaceAll(plugin, "-", "_")
+}